@@ -11,15 +11,17 @@ import (
 //Aggregate struct represents aggregated trading results
 //for the date by the security by markets
 type Aggregate struct {
-	MarketName   string  // "market_name"
-	MarketTitle  string  // "market_title"
-	Engine       string  // "engine"
-	TradeDate    string  // "tradedate"
-	SecurityId   string  // "secid"
-	Value        float64 // "value"
-	Volume       int64   // "volume"
-	NumberTrades int64   // "numtrades"
-	UpdatedAt    string  // "updated_at"
+	MarketName    string  // "market_name"
+	MarketTitle   string  // "market_title"
+	MarketTitleRu string  // "market_title" in Russian, it's filled by GetAggregatesBilingual only
+	MarketTitleEn string  // "market_title" in English, it's filled by GetAggregatesBilingual only
+	Engine        string  // "engine"
+	TradeDate     string  // "tradedate"
+	SecurityId    string  // "secid"
+	Value         float64 // "value"
+	Volume        int64   // "volume"
+	NumberTrades  int64   // "numtrades"
+	UpdatedAt     string  // "updated_at"
 }
 
 //AggregatesResponse struct represents a response with aggregated trading results
@@ -32,6 +34,7 @@ type AggregatesResponse struct {
 
 const (
 	aggregatesPartsUrl = "aggregates.json"
+	aggregatesEndpoint = "securities/{security}/aggregates"
 
 	aggKeyMarketName   = "market_name"
 	aggKeyMarketTitle  = "market_title"
@@ -62,7 +65,7 @@ func (a *AggregateService) GetAggregates(ctx context.Context, security string, o
 	if err != nil {
 		return nil, err
 	}
-	req, err := a.client.NewRequest("GET", url, nil)
+	req, err := a.client.newEndpointRequest(aggregatesEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -83,12 +86,52 @@ func (a *AggregateService) GetAggregates(ctx context.Context, security string, o
 	return &ar, nil
 }
 
+//AggregatesBatchItem represents a result of GetAggregatesBatch for one security
+type AggregatesBatchItem struct {
+	SecurityId string
+	Response   *AggregatesResponse
+	Err        error
+}
+
+//AggregatesBatchResponse struct represents a response of GetAggregatesBatch
+type AggregatesBatchResponse struct {
+	Items   []AggregatesBatchItem // in the order of the requested securities
+	Summary BatchSummary
+}
+
+//GetAggregatesBatch provides aggregated trading results of several securities.
+//Requests are sent concurrently by no more than Client.MaxBatchWorkers workers.
+//A failed request doesn't fail the whole batch, see AggregatesBatchItem.Err and Summary.
+func (a *AggregateService) GetAggregatesBatch(ctx context.Context, securities []string, opt *AggregateRequestOptions) (*AggregatesBatchResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		return nil, ErrNonNilContext
+	}
+	items := make([]AggregatesBatchItem, len(securities))
+	errs := make([]error, len(securities))
+	a.client.runBatch(ctx, len(securities), func(ctx context.Context, i int) {
+		items[i].Response, errs[i] = a.GetAggregates(ctx, securities[i], opt)
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+	for i := range items {
+		items[i].SecurityId = securities[i]
+		items[i].Err = errs[i]
+	}
+	return &AggregatesBatchResponse{Items: items, Summary: summarize(securities, errs)}, nil
+}
+
 //getUrl provides an url for a request of the aggregates with parameters from AggregateRequestOptions
 //opt *AggregateRequestOptions can be nil, it is safe
 func (a *AggregateService) getUrl(security string, opt *AggregateRequestOptions) (string, error) {
 	if !isOkSecurityParam(security) {
 		return "", ErrBadSecurityParameter
 	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
 	url, _ := a.client.BaseURL.Parse("securities")
 
 	url.Path = path.Join(url.Path, security, aggregatesPartsUrl)
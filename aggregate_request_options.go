@@ -28,6 +28,38 @@ func (b *AggregateReqOptionsBuilder) Build() *AggregateRequestOptions {
 	return b.options
 }
 
+//BuildValidated builds AggregateRequestOptions from AggregateReqOptionsBuilder
+//and returns *ValidationError if an option is invalid
+func (b *AggregateReqOptionsBuilder) BuildValidated() (*AggregateRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+//validate checks parameters of AggregateRequestOptions,
+//options can be nil, it is safe
+func (options *AggregateRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return firstError(
+		validateLang("lang", options.lang),
+		validateNotFutureDate("date", options.date),
+	)
+}
+
+//withLang returns a copy of the options with the language,
+//options can be nil, it is safe
+func (options *AggregateRequestOptions) withLang(lang Language) *AggregateRequestOptions {
+	opt := AggregateRequestOptions{}
+	if options != nil {
+		opt = *options
+	}
+	opt.lang = lang
+	return &opt
+}
+
 //Lang sets 'lang' parameter to a request
 func (b *AggregateReqOptionsBuilder) Lang(lang Language) *AggregateReqOptionsBuilder {
 	b.options.lang = lang
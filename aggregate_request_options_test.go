@@ -73,3 +73,16 @@ func TestAddAggregateRequestOptions(t *testing.T) {
 		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
 	}
 }
+
+func TestAggregateReqOptionsBuilder_BuildValidated(t *testing.T) {
+	checkBuilderCases(t, []builderCase{
+		{func() (bool, error) {
+			opt, err := NewAggregateReqOptionsBuilder().Date(time.Date(2021, 2, 24, 0, 0, 0, 0, time.UTC)).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewAggregateReqOptionsBuilder().Date(time.Now().AddDate(0, 0, 2)).BuildValidated()
+			return opt != nil, err
+		}, "date"},
+	})
+}
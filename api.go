@@ -46,15 +46,34 @@ type Client struct {
 	// User agent used when communicating with the MoEx Iss API.
 	UserAgent string
 
+	// MaxBatchWorkers limits the number of concurrent requests of batch methods
+	// like AggregateService.GetAggregatesBatch. defaultBatchWorkers is used if it isn't positive.
+	MaxBatchWorkers int
+
+	limiter *rateLimiter
+
+	middlewares []Middleware
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
-	Securities     *SecuritiesService
-	Index          *IndexService
-	Turnovers      *TurnoverService
-	Aggregates     *AggregateService
-	Indices        *IndicesService
-	HistoryListing *HistoryListingService
-	Stats          *StatsService
+	Securities       *SecuritiesService
+	Index            *IndexService
+	Turnovers        *TurnoverService
+	Aggregates       *AggregateService
+	Indices          *IndicesService
+	HistoryListing   *HistoryListingService
+	Stats            *StatsService
+	ZCYC             *ZCYCService
+	Rates            *RatesService
+	FutOI            *FutOIService
+	Derivatives      *DerivativesService
+	Engines          *EnginesService
+	SecurityGroups   *SecurityGroupsService
+	News             *NewsService
+	CorporateActions *CorporateActionsService
+	Archives         *ArchivesService
+	Capitalization   *CapitalizationService
+	Schedule         *ScheduleService
 }
 
 // NewClient creates an instance of Client
@@ -74,9 +93,27 @@ func NewClient(httpClient *http.Client) *Client {
 	c.Indices = (*IndicesService)(&c.common)
 	c.HistoryListing = (*HistoryListingService)(&c.common)
 	c.Stats = (*StatsService)(&c.common)
+	c.ZCYC = (*ZCYCService)(&c.common)
+	c.Rates = (*RatesService)(&c.common)
+	c.FutOI = (*FutOIService)(&c.common)
+	c.Derivatives = (*DerivativesService)(&c.common)
+	c.Engines = (*EnginesService)(&c.common)
+	c.SecurityGroups = (*SecurityGroupsService)(&c.common)
+	c.News = (*NewsService)(&c.common)
+	c.CorporateActions = (*CorporateActionsService)(&c.common)
+	c.Archives = (*ArchivesService)(&c.common)
+	c.Capitalization = (*CapitalizationService)(&c.common)
+	c.Schedule = (*ScheduleService)(&c.common)
 	return c
 }
 
+// SetRateLimit limits the number of requests per second sent by the client,
+// a value which isn't positive removes the limit.
+// Requests wait for their turn in BareDo, batch methods respect the limit as well.
+func (c *Client) SetRateLimit(requestsPerSecond float64) {
+	c.limiter = newRateLimiter(requestsPerSecond)
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash. If
@@ -121,7 +158,8 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response body will be written to v, without attempting to first
-// decode it. If v is nil, and no error happens, the response is returned as is.
+// decode it, v is flushed if it has Flush() error method. If v is nil, and no
+// error happens, the response is returned as is.
 //
 // The provided ctx must be non-nil, if it is nil an error is returned. If it
 // is canceled or times out, ctx.Err() will be returned.
@@ -135,6 +173,10 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	case nil:
 	case io.Writer:
 		_, err = io.Copy(v, resp.Body)
+		if f, ok := v.(interface{ Flush() error }); ok && err == nil {
+			// buffered writers keep the tail of the body until they are flushed
+			err = f.Flush()
+		}
 	default:
 		var b []byte
 		b, err = io.ReadAll(resp.Body)
@@ -162,15 +204,41 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 // BareDo sends an API request and lets you handle the api response. If an error
 // or API Error occurs, the error will contain more information. Otherwise you
 // are supposed to read and close the response's Body.
+// The request is passed through the middlewares added by Use.
 //
 // The provided ctx must be non-nil, if it is nil an error is returned. If it is
 // canceled or times out, ctx.Err() will be returned.
 func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response := &Response{resp}
+
+	err = CheckResponse(resp)
+	if err != nil {
+		clErr := resp.Body.Close()
+		if clErr != nil {
+			return nil, fmt.Errorf("got some errors: \n%s \nand \n%s", err.Error(), clErr.Error())
+		}
+		return nil, err
+	}
+	return response, err
+}
+
+// send sends an API request through the middlewares and returns the response
+// without checking its status
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
 	if ctx == nil {
 		return nil, ErrNonNilContext
 	}
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.roundTrip()(ctx, &Call{Request: req, Endpoint: endpointOf(req)})
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -188,18 +256,7 @@ func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, erro
 		return nil, err
 
 	}
-
-	response := &Response{resp}
-
-	err = CheckResponse(resp)
-	if err != nil {
-		clErr := resp.Body.Close()
-		if clErr != nil {
-			return nil, fmt.Errorf("got some errors: \n%s \nand \n%s", err.Error(), clErr.Error())
-		}
-		return nil, err
-	}
-	return response, err
+	return resp, nil
 }
 
 // CheckResponse checks the API response for errors, and returns them if
@@ -1,11 +1,17 @@
 package moexiss
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"errors"
+	"io"
 	"math"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestNewClientDefaultHttpClient(t *testing.T) {
@@ -178,3 +184,49 @@ func TestNewRequest(t *testing.T) {
 	}
 
 }
+
+type bytesReaderTransport struct {
+	body []byte
+}
+
+func (t bytesReaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       io.NopCloser(bytes.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestDoFlushesBufferedWriter(t *testing.T) {
+	body := []byte(`[{"turnovers": []}]`)
+	c := NewClient(&http.Client{Transport: bytesReaderTransport{body: body}})
+	req, err := c.NewRequest("GET", "turnovers.json", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	var b bytes.Buffer
+	_, err = c.Do(context.Background(), req, bufio.NewWriter(&b))
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := b.String(), string(body); got != expected {
+		t.Fatalf("Error: expecting body: %s \ngot %s \ninstead", expected, got)
+	}
+}
+
+func TestBareDoContextTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	req, _ := c.NewRequest("GET", "turnovers.json", nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.BareDo(ctx, req); err != context.DeadlineExceeded {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,596 @@
+package moexiss
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ArchiveDataType is a type of data of MoEx ISS archives
+type ArchiveDataType string
+
+// A list of data types of MoEx ISS archives
+const (
+	ArchiveSecurities ArchiveDataType = "securities" // daily results of trading, see ReadHistoryRecords
+	ArchiveTrades     ArchiveDataType = "trades"     // trades, see ReadArchiveTrades
+)
+
+// String representations of ArchiveDataType value
+func (dt ArchiveDataType) String() string {
+	return string(dt)
+}
+
+// ArchivePeriod is a period of MoEx ISS archives
+type ArchivePeriod string
+
+// A list of periods of MoEx ISS archives
+const (
+	ArchiveYearly  ArchivePeriod = "years"
+	ArchiveMonthly ArchivePeriod = "months"
+)
+
+// String representations of ArchivePeriod value
+func (p ArchivePeriod) String() string {
+	return string(p)
+}
+
+// ArchiveFile struct represents a zipped CSV file of MoEx ISS archives
+type ArchiveFile struct {
+	DataType ArchiveDataType
+	Period   ArchivePeriod
+	Year     int64  // "year"
+	Month    int64  // "month" It's zero for yearly archives
+	Url      string // "url" Link to the zipped CSV file
+}
+
+// HistoryRecord struct represents daily results of trading of a security on a board
+// from a CSV file of ArchiveSecurities archives.
+// Prices are zero values if there were no trades.
+type HistoryRecord struct {
+	BoardId         string    // "BOARDID"
+	TradeDate       time.Time // "TRADEDATE" in MoscowLocation
+	ShortName       string    // "SHORTNAME"
+	SecurityId      string    // "SECID"
+	NumTrades       int64     // "NUMTRADES"
+	Value           float64   // "VALUE"
+	Open            float64   // "OPEN"
+	Low             float64   // "LOW"
+	High            float64   // "HIGH"
+	LegalClosePrice float64   // "LEGALCLOSEPRICE"
+	WaPrice         float64   // "WAPRICE"
+	Close           float64   // "CLOSE"
+	Volume          float64   // "VOLUME"
+}
+
+// DailyPrice returns daily prices of the record to be adjusted by AdjustPrices
+func (hr HistoryRecord) DailyPrice() DailyPrice {
+	return DailyPrice{
+		SecurityId: hr.SecurityId,
+		Date:       hr.TradeDate,
+		Open:       hr.Open,
+		High:       hr.High,
+		Low:        hr.Low,
+		Close:      hr.Close,
+		Volume:     hr.Volume,
+	}
+}
+
+// ArchiveTrade struct represents a trade from a CSV file of ArchiveTrades archives
+type ArchiveTrade struct {
+	TradeNo    int64     // "TRADENO"
+	TradeTime  time.Time // "TRADEDATE" and "TRADETIME" in MoscowLocation
+	BoardId    string    // "BOARDID"
+	SecurityId string    // "SECID"
+	Price      float64   // "PRICE"
+	Quantity   int64     // "QUANTITY"
+	Value      float64   // "VALUE"
+	BuySell    string    // "BUYSELL" "B" or "S"
+}
+
+const (
+	archivesPartOfPath     = "archives"
+	archivesFileExtension  = ".json"
+	archivePartFileSuffix  = ".part"
+	archiveCsvHeaderColumn = "SECID"
+	// archiveCsvPeekSize is the size of the beginning of the CSV data looked through for the header line
+	archiveCsvPeekSize = 4096
+
+	// archiveDownloadAttempts is the number of attempts of ArchivesService.Download,
+	// every attempt resumes the partially downloaded file
+	archiveDownloadAttempts = 3
+
+	archiveKeyYear  = "year"
+	archiveKeyMonth = "month"
+	archiveKeyUrl   = "url"
+
+	archiveColBoardId         = "BOARDID"
+	archiveColTradeDate       = "TRADEDATE"
+	archiveColShortName       = "SHORTNAME"
+	archiveColSecId           = "SECID"
+	archiveColNumTrades       = "NUMTRADES"
+	archiveColValue           = "VALUE"
+	archiveColOpen            = "OPEN"
+	archiveColLow             = "LOW"
+	archiveColHigh            = "HIGH"
+	archiveColLegalClosePrice = "LEGALCLOSEPRICE"
+	archiveColWaPrice         = "WAPRICE"
+	archiveColClose           = "CLOSE"
+	archiveColVolume          = "VOLUME"
+	archiveColTradeNo         = "TRADENO"
+	archiveColTradeTime       = "TRADETIME"
+	archiveColPrice           = "PRICE"
+	archiveColQuantity        = "QUANTITY"
+	archiveColBuySell         = "BUYSELL"
+)
+
+const (
+	archivesEndpoint = "archives/engines/{engine}/markets/{market}/{datatype}/{period}"
+	// archiveFileEndpoint is the endpoint of ArchiveFile.Url
+	archiveFileEndpoint = "downloads/engines/{engine}/markets/{market}/{datatype}/{period}/{file}"
+)
+
+// ArchivesService gets lists of archives of MoEx ISS and downloads them.
+// It's faster for backfills than per-security history pages.
+//
+// MoEx ISS API endpoint:
+// https://iss.moex.com/iss/archives/engines/[engine]/markets/[market]/[datatype]/[period]
+type ArchivesService service
+
+// List provides a list of archive files of the market with the data type for the period
+func (a *ArchivesService) List(ctx context.Context, engine EngineName, market string, dataType ArchiveDataType, period ArchivePeriod, opt *ArchivesRequestOptions) ([]ArchiveFile, error) {
+	url, err := a.getUrl(engine, market, dataType, period, opt)
+	if err != nil {
+		return nil, err
+	}
+	req, err := a.client.newEndpointRequest(archivesEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = a.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]ArchiveFile, 0)
+	err = parseBlockWithCursor(b.Bytes(), period.String(), nil, func(data []byte) error {
+		file := ArchiveFile{DataType: dataType, Period: period}
+		errParse := parseArchiveFile(data, &file)
+		if errParse == nil {
+			files = append(files, file)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Download downloads the archive file into dst.
+// The file is downloaded into dst with ".part" suffix and renamed to dst when it's complete,
+// so a download after a partial failure resumes the ".part" file by a range request.
+// A complete ".part" file left by a failure before the rename is just renamed
+// if the server reports the size of the file, otherwise it's downloaded again.
+// The download is resumed up to archiveDownloadAttempts times before the last error is returned.
+func (a *ArchivesService) Download(ctx context.Context, file ArchiveFile, dst string) error {
+	if ctx == nil {
+		return ErrNonNilContext
+	}
+	if file.Url == "" {
+		return ErrBadArchiveParameter
+	}
+	partName := dst + archivePartFileSuffix
+	var err error
+	for attempt := 0; attempt < archiveDownloadAttempts; attempt++ {
+		err = a.downloadPart(ctx, file.Url, partName)
+		if err == nil {
+			return os.Rename(partName, dst)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// downloadPart requests the rest of the file which isn't in partName yet and appends it to partName
+func (a *ArchivesService) downloadPart(ctx context.Context, fileUrl string, partName string) error {
+	var offset int64
+	if info, err := os.Stat(partName); err == nil {
+		offset = info.Size()
+	}
+	req, err := a.client.newEndpointRequest(archiveFileEndpoint, fileUrl)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := a.client.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// the part file may already hold the whole file, e.g. after a failure before the rename
+		if size, ok := archiveRangeSize(resp.Header.Get("Content-Range")); ok && size == offset {
+			return nil
+		}
+		// the part file can't be checked or doesn't match the file on the server,
+		// it's downloaded again by the next attempt
+		if err = os.Remove(partName); err != nil {
+			return err
+		}
+	}
+	if err = CheckResponse(resp); err != nil {
+		return err
+	}
+
+	flag := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if resp.StatusCode != http.StatusPartialContent {
+		// the server ignores the range, the file is sent from the beginning
+		flag = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	f, err := os.OpenFile(partName, flag, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, resp.Body)
+	if clErr := f.Close(); err == nil {
+		err = clErr
+	}
+	return err
+}
+
+// archiveRangeSize returns the size of the file from "bytes */<size>" value of Content-Range header
+// of a response with http.StatusRequestedRangeNotSatisfiable status
+func archiveRangeSize(contentRange string) (int64, bool) {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return size, true
+}
+
+// getUrl provides an url for a request of a list of archives
+// opt *ArchivesRequestOptions can be nil, it is safe
+func (a *ArchivesService) getUrl(engine EngineName, market string, dataType ArchiveDataType, period ArchivePeriod, opt *ArchivesRequestOptions) (string, error) {
+	if engine == EngineUndefined {
+		return "", ErrBadEngineParameter
+	}
+	marketMinLen := 3
+	if market == "" || utf8.RuneCountInString(market) < marketMinLen {
+		return "", ErrBadMarketParameter
+	}
+	if dataType != ArchiveSecurities && dataType != ArchiveTrades {
+		return "", ErrBadArchiveParameter
+	}
+	if period != ArchiveYearly && period != ArchiveMonthly {
+		return "", ErrBadArchiveParameter
+	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
+	url, _ := a.client.BaseURL.Parse(archivesPartOfPath)
+	url.Path = path.Join(url.Path, enginePartOfPath, engine.String(), marketsPartOfPath, market, dataType.String(), period.String())
+	url.Path += archivesFileExtension
+	gotURL := addArchivesRequestOptions(url, opt)
+	return gotURL.String(), nil
+}
+
+// UnpackArchive extracts the files of the zip archive src into the directory dir
+// and returns paths of the extracted files.
+// Paths of the files inside the archive are dropped, only their base names are kept.
+func UnpackArchive(src string, dir string) ([]string, error) {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	files := make([]string, 0, len(r.File))
+	for _, zf := range r.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		name := filepath.Join(dir, filepath.Base(zf.Name))
+		err = unpackArchiveFile(zf, name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+func unpackArchiveFile(zf *zip.File, name string) error {
+	rc, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, rc)
+	if clErr := f.Close(); err == nil {
+		err = clErr
+	}
+	return err
+}
+
+// ReadHistoryRecords decodes the CSV file of ArchiveSecurities archives.
+// Lines before the header line are skipped, values are separated by ';' or ','.
+func ReadHistoryRecords(r io.Reader) ([]HistoryRecord, error) {
+	records := make([]HistoryRecord, 0)
+	err := readArchiveCsv(r, func(row archiveRow) error {
+		hr := HistoryRecord{}
+		errParse := row.parseHistoryRecord(&hr)
+		if errParse == nil {
+			records = append(records, hr)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ReadArchiveTrades decodes the CSV file of ArchiveTrades archives.
+// Lines before the header line are skipped, values are separated by ';' or ','.
+func ReadArchiveTrades(r io.Reader) ([]ArchiveTrade, error) {
+	trades := make([]ArchiveTrade, 0)
+	err := readArchiveCsv(r, func(row archiveRow) error {
+		at := ArchiveTrade{}
+		errParse := row.parseArchiveTrade(&at)
+		if errParse == nil {
+			trades = append(trades, at)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
+
+// archiveRow represents a row of a CSV file of archives with its header
+type archiveRow struct {
+	line    int
+	columns map[string]int
+	values  []string
+}
+
+// readArchiveCsv calls parseRow for every row after the header line
+func readArchiveCsv(r io.Reader, parseRow func(row archiveRow) error) error {
+	br := bufio.NewReaderSize(r, archiveCsvPeekSize)
+	data, err := br.Peek(archiveCsvPeekSize)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	cr := csv.NewReader(br)
+	cr.Comma = archiveCsvComma(string(data))
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var columns map[string]int
+	for line := 1; ; line++ {
+		values, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if columns == nil {
+			columns = archiveCsvHeader(values)
+			continue
+		}
+		err = parseRow(archiveRow{line: line, columns: columns, values: values})
+		if err != nil {
+			return err
+		}
+	}
+	if columns == nil {
+		return ErrEmptyServerResult
+	}
+	return nil
+}
+
+// archiveCsvComma returns the separator of the header line of the beginning of the CSV data
+func archiveCsvComma(data string) rune {
+	for _, line := range strings.Split(data, "\n") {
+		if strings.Contains(line, archiveCsvHeaderColumn) {
+			if strings.Contains(line, ";") {
+				return ';'
+			}
+			return ','
+		}
+	}
+	return ';'
+}
+
+// archiveCsvHeader returns the indexes of the columns if the values are the header line
+func archiveCsvHeader(values []string) map[string]int {
+	columns := make(map[string]int)
+	for i, v := range values {
+		columns[strings.TrimSpace(strings.TrimPrefix(v, "\ufeff"))] = i
+	}
+	if _, ok := columns[archiveCsvHeaderColumn]; !ok {
+		return nil
+	}
+	return columns
+}
+
+// str returns the value of the column, it's empty if there is no such column
+func (row archiveRow) str(column string) string {
+	i, ok := row.columns[column]
+	if !ok || i >= len(row.values) {
+		return ""
+	}
+	return strings.TrimSpace(row.values[i])
+}
+
+func (row archiveRow) float(column string) (float64, error) {
+	value := row.str(column)
+	if value == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("line %d, column %s: %w", row.line, column, err)
+	}
+	return f, nil
+}
+
+func (row archiveRow) int(column string) (int64, error) {
+	value := row.str(column)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("line %d, column %s: %w", row.line, column, err)
+	}
+	return i, nil
+}
+
+func (row archiveRow) dateTime(dateColumn string, timeColumn string) (time.Time, error) {
+	t, err := parseDateTimeWithDefaultValue(row.str(dateColumn), row.str(timeColumn))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("line %d, column %s: %w", row.line, dateColumn, err)
+	}
+	return t, nil
+}
+
+func (row archiveRow) parseHistoryRecord(hr *HistoryRecord) (err error) {
+	tradeDate, err := row.dateTime(archiveColTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	var floats [8]float64
+	floatColumns := [8]string{
+		archiveColValue,
+		archiveColOpen,
+		archiveColLow,
+		archiveColHigh,
+		archiveColLegalClosePrice,
+		archiveColWaPrice,
+		archiveColClose,
+		archiveColVolume,
+	}
+	for i, column := range floatColumns {
+		if floats[i], err = row.float(column); err != nil {
+			return
+		}
+	}
+
+	numTrades, err := row.int(archiveColNumTrades)
+	if err != nil {
+		return
+	}
+
+	hr.BoardId = row.str(archiveColBoardId)
+	hr.TradeDate = tradeDate
+	hr.ShortName = row.str(archiveColShortName)
+	hr.SecurityId = row.str(archiveColSecId)
+	hr.NumTrades = numTrades
+	hr.Value = floats[0]
+	hr.Open = floats[1]
+	hr.Low = floats[2]
+	hr.High = floats[3]
+	hr.LegalClosePrice = floats[4]
+	hr.WaPrice = floats[5]
+	hr.Close = floats[6]
+	hr.Volume = floats[7]
+
+	return
+}
+
+func (row archiveRow) parseArchiveTrade(at *ArchiveTrade) (err error) {
+	tradeNo, err := row.int(archiveColTradeNo)
+	if err != nil {
+		return
+	}
+
+	tradeTime, err := row.dateTime(archiveColTradeDate, archiveColTradeTime)
+	if err != nil {
+		return
+	}
+
+	price, err := row.float(archiveColPrice)
+	if err != nil {
+		return
+	}
+
+	quantity, err := row.int(archiveColQuantity)
+	if err != nil {
+		return
+	}
+
+	value, err := row.float(archiveColValue)
+	if err != nil {
+		return
+	}
+
+	at.TradeNo = tradeNo
+	at.TradeTime = tradeTime
+	at.BoardId = row.str(archiveColBoardId)
+	at.SecurityId = row.str(archiveColSecId)
+	at.Price = price
+	at.Quantity = quantity
+	at.Value = value
+	at.BuySell = row.str(archiveColBuySell)
+
+	return
+}
+
+func parseArchiveFile(data []byte, file *ArchiveFile) (err error) {
+	year, err := parseIntWithDefaultValue(data, archiveKeyYear)
+	if err != nil {
+		return
+	}
+
+	var month int64
+	if hasKey(data, archiveKeyMonth) {
+		month, err = parseIntWithDefaultValue(data, archiveKeyMonth)
+		if err != nil {
+			return
+		}
+	}
+
+	url, err := parseStringWithDefaultValueByKey(data, archiveKeyUrl, "")
+	if err != nil {
+		return
+	}
+
+	file.Year = year
+	file.Month = month
+	file.Url = url
+
+	return
+}
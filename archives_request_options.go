@@ -0,0 +1,70 @@
+package moexiss
+
+import "net/url"
+
+// ArchivesRequestOptions contains options which can be used as arguments
+// for building requests to get a list of archives.
+// MoEx ISS API endpoint:
+//
+// https://iss.moex.com/iss/archives/engines/[engine]/markets/[market]/[datatype]/[period]
+type ArchivesRequestOptions struct {
+	lang Language // `lang` query parameter in url.URL
+}
+
+// ArchivesReqOptionsBuilder represents a builder of ArchivesRequestOptions struct
+type ArchivesReqOptionsBuilder struct {
+	options *ArchivesRequestOptions
+}
+
+// NewArchivesReqOptionsBuilder is a constructor of ArchivesReqOptionsBuilder
+func NewArchivesReqOptionsBuilder() *ArchivesReqOptionsBuilder {
+	return &ArchivesReqOptionsBuilder{options: &ArchivesRequestOptions{}}
+}
+
+// Build builds ArchivesRequestOptions from ArchivesReqOptionsBuilder
+func (b *ArchivesReqOptionsBuilder) Build() *ArchivesRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds ArchivesRequestOptions from ArchivesReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *ArchivesReqOptionsBuilder) BuildValidated() (*ArchivesRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of ArchivesRequestOptions,
+// options can be nil, it is safe
+func (options *ArchivesRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *ArchivesReqOptionsBuilder) Lang(lang Language) *ArchivesReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// addArchivesRequestOptions sets parameters into *url.URL
+// from ArchivesRequestOptions struct and returns it back
+func addArchivesRequestOptions(url *url.URL, options *ArchivesRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
@@ -0,0 +1,61 @@
+package moexiss
+
+import (
+	"testing"
+)
+
+func TestArchivesReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := ArchivesRequestOptions{}
+	bld := NewArchivesReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` ArchivesRequestOptions \ngot `%v` ArchivesRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestArchivesReqOptionsBuilder_Lang(t *testing.T) {
+	expectStruct := ArchivesRequestOptions{lang: LangEn}
+	bld := NewArchivesReqOptionsBuilder()
+	bld.Lang(LangEn)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewArchivesRequestOptions(t *testing.T) {
+	expectStruct := ArchivesRequestOptions{
+		lang: LangRu,
+	}
+	bld := NewArchivesReqOptionsBuilder()
+	bld.Lang(LangRu)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+
+}
+
+func TestAddArchivesRequestOptionsNilOptions(t *testing.T) {
+	var income *ArchivesRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addArchivesRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddArchivesRequestOptions(t *testing.T) {
+	var incomeOptions = NewArchivesReqOptionsBuilder().
+		Lang(LangEn).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addArchivesRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&lang=en`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,423 @@
+package moexiss
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func getArchivesSrv(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fileName := "archives_years.json"
+		if strings.HasSuffix(r.URL.Path, "/months.json") {
+			fileName = "archives_months.json"
+		}
+		byteValue, err := getTestingData(fileName)
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+}
+
+// getTestingZip returns a zip archive with the testing CSV file of securities
+func getTestingZip(t *testing.T) []byte {
+	csvData, err := getTestingData("archive_securities.csv")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	f, err := zw.Create("2023/securities_2023.csv")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	_, _ = f.Write(csvData)
+	if err = zw.Close(); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	return b.Bytes()
+}
+
+func TestArchivesGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	got, err := c.Archives.getUrl(EngineStock, "shares", ArchiveSecurities, ArchiveYearly, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	expected := `https://iss.moex.com/iss/archives/engines/stock/markets/shares/securities/years.json?iss.json=extended&iss.meta=off`
+	if got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestArchivesGetUrlBadParams(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.Archives.getUrl(EngineUndefined, "shares", ArchiveSecurities, ArchiveYearly, nil); err != ErrBadEngineParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadEngineParameter, err)
+	}
+	if _, err := c.Archives.getUrl(EngineStock, "sh", ArchiveSecurities, ArchiveYearly, nil); err != ErrBadMarketParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadMarketParameter, err)
+	}
+	if _, err := c.Archives.getUrl(EngineStock, "shares", ArchiveDataType("orders"), ArchiveYearly, nil); err != ErrBadArchiveParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadArchiveParameter, err)
+	}
+	if _, err := c.Archives.getUrl(EngineStock, "shares", ArchiveTrades, ArchivePeriod("days"), nil); err != ErrBadArchiveParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadArchiveParameter, err)
+	}
+}
+
+func TestParseArchiveFile(t *testing.T) {
+	expectedStruct := ArchiveFile{Year: 2023, Month: 12, Url: "https://iss.moex.com/iss/downloads/2023-12.zip"}
+	file := ArchiveFile{}
+	if err := parseArchiveFile([]byte(`{"year": 2023, "month": 12, "url": "https://iss.moex.com/iss/downloads/2023-12.zip"}`), &file); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := file, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseArchiveFile([]byte(`{"year": 2023}`), &file), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestArchivesService_List(t *testing.T) {
+	srv := getArchivesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	files, err := c.Archives.List(context.Background(), EngineStock, "shares", ArchiveSecurities, ArchiveYearly, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(files), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if files[2].Year != 2023 || files[2].Month != 0 || files[2].DataType != ArchiveSecurities || files[2].Period != ArchiveYearly {
+		t.Fatalf("Error: unexpected archive file: \n %v", files[2])
+	}
+
+	files, err = c.Archives.List(context.Background(), EngineStock, "shares", ArchiveTrades, ArchiveMonthly, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := files[1].Month, int64(12); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestArchivesService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.Archives.List(context.Background(), EngineStock, "shares", ArchiveSecurities, ArchiveYearly, nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestArchivesService_DownloadResume(t *testing.T) {
+	content := getTestingZip(t)
+	ranges := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			// the first response is broken after a half of the file
+			w.Header().Set("Content-Length", "100000")
+			_, _ = w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "2023.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	dst := filepath.Join(t.TempDir(), "2023.zip")
+	err := c.Archives.Download(context.Background(), ArchiveFile{Url: srv.URL + "/2023.zip"}, dst)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(ranges), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v requests\ngot:\n %v requests\ninstead", expected, got)
+	}
+	if ranges[1] == "" {
+		t.Fatalf("Error: expecting a range request \ngot %v \ninstead", ranges)
+	}
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Error: expecting the downloaded file of %d bytes \ngot %d bytes \ninstead", len(content), len(got))
+	}
+	if _, err = os.Stat(dst + archivePartFileSuffix); !os.IsNotExist(err) {
+		t.Fatalf("Error: expecting no part file \ngot %v \ninstead", err)
+	}
+}
+
+func TestArchivesService_DownloadRangeIgnored(t *testing.T) {
+	content := getTestingZip(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	dst := filepath.Join(t.TempDir(), "2023.zip")
+	if err := os.WriteFile(dst+archivePartFileSuffix, []byte("broken"), 0644); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if err := c.Archives.Download(context.Background(), ArchiveFile{Url: srv.URL + "/2023.zip"}, dst); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	got, _ := os.ReadFile(dst)
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Error: expecting the downloaded file of %d bytes \ngot %d bytes \ninstead", len(content), len(got))
+	}
+}
+
+func TestArchivesService_DownloadCompletePart(t *testing.T) {
+	content := getTestingZip(t)
+	statuses := make([]int, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &statusRecorder{ResponseWriter: w}
+		http.ServeContent(rw, r, "2023.zip", time.Time{}, bytes.NewReader(content))
+		statuses = append(statuses, rw.status)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	dst := filepath.Join(t.TempDir(), "2023.zip")
+	// the part file is complete, e.g. the previous download failed before the rename
+	if err := os.WriteFile(dst+archivePartFileSuffix, content, 0644); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if err := c.Archives.Download(context.Background(), ArchiveFile{Url: srv.URL + "/2023.zip"}, dst); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if expected := []int{http.StatusRequestedRangeNotSatisfiable}; !reflect.DeepEqual(statuses, expected) {
+		t.Fatalf("Error: expecting statuses %v \ngot %v \ninstead", expected, statuses)
+	}
+	got, _ := os.ReadFile(dst)
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Error: expecting the downloaded file of %d bytes \ngot %d bytes \ninstead", len(content), len(got))
+	}
+	if _, err := os.Stat(dst + archivePartFileSuffix); !os.IsNotExist(err) {
+		t.Fatalf("Error: expecting no part file \ngot %v \ninstead", err)
+	}
+}
+
+func TestArchivesService_DownloadOversizedPart(t *testing.T) {
+	content := getTestingZip(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "2023.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	dst := filepath.Join(t.TempDir(), "2023.zip")
+	if err := os.WriteFile(dst+archivePartFileSuffix, append(content, content...), 0644); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if err := c.Archives.Download(context.Background(), ArchiveFile{Url: srv.URL + "/2023.zip"}, dst); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	got, _ := os.ReadFile(dst)
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Error: expecting the downloaded file of %d bytes \ngot %d bytes \ninstead", len(content), len(got))
+	}
+}
+
+func TestArchivesService_DownloadPartWithoutContentRange(t *testing.T) {
+	content := getTestingZip(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			// the size of the file isn't reported
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	dst := filepath.Join(t.TempDir(), "2023.zip")
+	if err := os.WriteFile(dst+archivePartFileSuffix, content[:len(content)/2], 0644); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if err := c.Archives.Download(context.Background(), ArchiveFile{Url: srv.URL + "/2023.zip"}, dst); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	got, _ := os.ReadFile(dst)
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Error: expecting the downloaded file of %d bytes \ngot %d bytes \ninstead", len(content), len(got))
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func TestArchivesService_DownloadErrCases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	dst := filepath.Join(t.TempDir(), "2023.zip")
+	if err := c.Archives.Download(context.Background(), ArchiveFile{Url: srv.URL + "/2023.zip"}, dst); err == nil {
+		t.Fatalf("Error: expecting error \ngot <nil> \ninstead")
+	}
+	if err := c.Archives.Download(context.Background(), ArchiveFile{}, dst); err != ErrBadArchiveParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadArchiveParameter, err)
+	}
+	var ctx context.Context = nil
+	if err := c.Archives.Download(ctx, ArchiveFile{Url: srv.URL}, dst); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
+
+func TestUnpackArchive(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "2023.zip")
+	if err := os.WriteFile(src, getTestingZip(t), 0644); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	files, err := UnpackArchive(src, dir)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(files), 1; got != expected {
+		t.Fatalf("Error: expecting: \n %v files\ngot:\n %v files\ninstead", expected, got)
+	}
+	if got, expected := files[0], filepath.Join(dir, "securities_2023.csv"); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	defer f.Close()
+	records, err := ReadHistoryRecords(f)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(records), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v records\ngot:\n %v records\ninstead", expected, got)
+	}
+}
+
+func TestReadHistoryRecords(t *testing.T) {
+	data, err := getTestingData("archive_securities.csv")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	records, err := ReadHistoryRecords(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	expectedStruct := HistoryRecord{
+		BoardId:         "TQBR",
+		TradeDate:       time.Date(2023, 12, 28, 0, 0, 0, 0, MoscowLocation),
+		ShortName:       "Сбербанк",
+		SecurityId:      "SBER",
+		NumTrades:       118411,
+		Value:           13017236475.8,
+		Open:            271.5,
+		Low:             270.62,
+		High:            272.2,
+		LegalClosePrice: 271.37,
+		WaPrice:         271.36,
+		Close:           271.37,
+		Volume:          47968420,
+	}
+	if got, expected := records[0], expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	// no trades on the board
+	if got, expected := records[3].Close, 0.0; got != expected || records[3].Volume != 29 {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := records[0].DailyPrice().Close, 271.37; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestReadArchiveTrades(t *testing.T) {
+	data, err := getTestingData("archive_trades.csv")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	trades, err := ReadArchiveTrades(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	expectedStruct := ArchiveTrade{
+		TradeNo:    9283712332,
+		TradeTime:  time.Date(2023, 12, 29, 10, 0, 1, 0, MoscowLocation),
+		BoardId:    "TQBR",
+		SecurityId: "SBER",
+		Price:      271.88,
+		Quantity:   3,
+		Value:      8156.4,
+		BuySell:    "S",
+	}
+	if got, expected := len(trades), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v trades\ngot:\n %v trades\ninstead", expected, got)
+	}
+	if got, expected := trades[1], expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestReadArchiveTradesStream(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("TRADENO,SECID,PRICE\n")
+	for i := 0; b.Len() <= archiveCsvPeekSize; i++ {
+		_, _ = fmt.Fprintf(&b, "%d,SBER,271.9\n", i)
+	}
+	rows := strings.Count(b.String(), "\n") - 1
+	// the data is read by one byte so it doesn't fit in a single read
+	trades, err := ReadArchiveTrades(iotest.OneByteReader(strings.NewReader(b.String())))
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(trades), rows; got != expected {
+		t.Fatalf("Error: expecting: \n %v trades\ngot:\n %v trades\ninstead", expected, got)
+	}
+	if got, expected := trades[rows-1].TradeNo, int64(rows-1); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestReadArchiveCsvErrCases(t *testing.T) {
+	if _, err := ReadHistoryRecords(strings.NewReader("history\n")); err != ErrEmptyServerResult {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrEmptyServerResult, err)
+	}
+	_, err := ReadArchiveTrades(strings.NewReader("TRADENO,SECID,PRICE\n1,SBER,271.9\n2,SBER,abc\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3, column PRICE") {
+		t.Fatalf("Error: expecting an error of the line 3 \ngot %v \ninstead", err)
+	}
+}
@@ -0,0 +1,86 @@
+package moexiss
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBatchWorkers is the number of concurrent requests of batch methods by default
+const defaultBatchWorkers = 4
+
+// BatchSummary represents a summary of a batch request
+type BatchSummary struct {
+	Total     int      // the number of requested securities
+	Succeeded int      // the number of successful requests
+	Failed    int      // the number of failed requests
+	FailedIds []string // securities of the failed requests
+}
+
+// IsPartial reports whether the batch request succeeded for some securities only
+func (s BatchSummary) IsPartial() bool {
+	return s.Succeeded > 0 && s.Failed > 0
+}
+
+// batchWorkers returns the number of workers for a batch of n requests
+func (c *Client) batchWorkers(n int) int {
+	workers := c.MaxBatchWorkers
+	if workers <= 0 {
+		workers = defaultBatchWorkers
+	}
+	if workers > n {
+		workers = n
+	}
+	return workers
+}
+
+// runBatch calls fn for every index from 0 to n-1 by a bounded pool of workers
+// and waits for all the calls to finish.
+// fn isn't called for the rest of indexes once ctx is done, errFn is called for them instead.
+func (c *Client) runBatch(ctx context.Context, n int, fn func(ctx context.Context, i int), errFn func(i int, err error)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < c.batchWorkers(n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errFn(i, err)
+					continue
+				}
+				fn(ctx, i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// firstCauseError returns the first error of a batch which is canceled on the first failure,
+// the requests canceled by the failed one aren't the cause
+func firstCauseError(errs []error) error {
+	var firstErr error
+	for _, err := range errs {
+		if err != nil && (firstErr == nil || firstErr == context.Canceled) {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// summarize returns BatchSummary of a batch request by errors of its items
+func summarize(ids []string, errs []error) BatchSummary {
+	summary := BatchSummary{Total: len(ids)}
+	for i, err := range errs {
+		if err != nil {
+			summary.Failed++
+			summary.FailedIds = append(summary.FailedIds, ids[i])
+			continue
+		}
+		summary.Succeeded++
+	}
+	return summary
+}
@@ -0,0 +1,83 @@
+package moexiss
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestRunBatchBoundedWorkers(t *testing.T) {
+	c := NewClient(nil)
+	c.MaxBatchWorkers = 3
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	done := make([]bool, 20)
+	c.runBatch(context.Background(), len(done), func(ctx context.Context, i int) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		mu.Lock()
+		done[i] = true
+		running--
+		mu.Unlock()
+	}, func(i int, err error) {
+		t.Errorf("Error: unexpected error %v in %d item", err, i)
+	})
+	if maxRunning > c.MaxBatchWorkers {
+		t.Fatalf("Error: expecting no more than %d workers \ngot %d \ninstead", c.MaxBatchWorkers, maxRunning)
+	}
+	for i, ok := range done {
+		if !ok {
+			t.Fatalf("Error: expecting %d item to be done", i)
+		}
+	}
+}
+
+func TestRunBatchCanceledContext(t *testing.T) {
+	c := NewClient(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs := make([]error, 5)
+	c.runBatch(ctx, len(errs), func(ctx context.Context, i int) {
+		t.Errorf("Error: unexpected call for %d item", i)
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+	for i, err := range errs {
+		if got, expected := err, context.Canceled; got != expected {
+			t.Fatalf("Error: expecting %v error \ngot %v \ninstead in %d item", expected, got, i)
+		}
+	}
+}
+
+func TestBatchWorkers(t *testing.T) {
+	c := NewClient(nil)
+	if got, expected := c.batchWorkers(100), defaultBatchWorkers; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	c.MaxBatchWorkers = 10
+	if got, expected := c.batchWorkers(2), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary := summarize([]string{"SBER", "BAD", "GAZP"}, []error{nil, errors.New("failed"), nil})
+	if got, expected := summary.Succeeded, 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := summary.Failed, 1; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if len(summary.FailedIds) != 1 || summary.FailedIds[0] != "BAD" {
+		t.Fatalf("Error: expecting [BAD] failed ids \ngot %v \ninstead", summary.FailedIds)
+	}
+	if !summary.IsPartial() {
+		t.Fatalf("Error: expecting a partial success")
+	}
+}
@@ -0,0 +1,174 @@
+package moexiss
+
+import (
+	"context"
+	"strconv"
+)
+
+// bilingualLangs are the languages of bilingual requests, the first one is the language of the result
+var bilingualLangs = []Language{LangRu, LangEn}
+
+// ListBilingual provides the index with titles both in Russian and in English,
+// the index is requested in both languages concurrently and the English titles are joined
+// to the Russian entries by their keys. Title fields keep the Russian titles.
+// The languages of opt are ignored.
+func (s *IndexService) ListBilingual(ctx context.Context, opt *IndexRequestOptions) (*Index, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	indexes := make([]*Index, len(bilingualLangs))
+	err := s.client.getBilingual(ctx, func(ctx context.Context, i int) (err error) {
+		indexes[i], err = s.List(ctx, opt.withLang(bilingualLangs[i]))
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	mergeIndexTitles(indexes[0], indexes[1])
+	return indexes[0], nil
+}
+
+// GetAggregatesBilingual provides aggregated trading results with market titles
+// both in Russian and in English, MarketTitle keeps the Russian title.
+// The language of opt is ignored.
+func (a *AggregateService) GetAggregatesBilingual(ctx context.Context, security string, opt *AggregateRequestOptions) (*AggregatesResponse, error) {
+	if _, err := a.getUrl(security, opt); err != nil {
+		return nil, err
+	}
+	responses := make([]*AggregatesResponse, len(bilingualLangs))
+	err := a.client.getBilingual(ctx, func(ctx context.Context, i int) (err error) {
+		responses[i], err = a.GetAggregates(ctx, security, opt.withLang(bilingualLangs[i]))
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	titlesEn := make(map[string]string, len(responses[1].Aggregates))
+	for _, ag := range responses[1].Aggregates {
+		titlesEn[ag.Engine+"/"+ag.MarketName] = ag.MarketTitle
+	}
+	ar := responses[0]
+	for i := range ar.Aggregates {
+		ag := &ar.Aggregates[i]
+		ag.MarketTitleRu = ag.MarketTitle
+		ag.MarketTitleEn = titlesEn[ag.Engine+"/"+ag.MarketName]
+	}
+	return ar, nil
+}
+
+// GetTurnoversBilingual provides turnovers of markets with titles
+// both in Russian and in English, Title keeps the Russian title.
+// The language of opt is ignored.
+func (s *TurnoverService) GetTurnoversBilingual(ctx context.Context, opt *TurnoverRequestOptions) (*[]Turnover, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	turnovers := make([]*[]Turnover, len(bilingualLangs))
+	err := s.client.getBilingual(ctx, func(ctx context.Context, i int) (err error) {
+		turnovers[i], err = s.GetTurnovers(ctx, opt.withLang(bilingualLangs[i]))
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	titlesEn := make(map[string]string, len(*turnovers[1]))
+	for _, t := range *turnovers[1] {
+		titlesEn[t.Name] = t.Title
+	}
+	t := *turnovers[0]
+	for i := range t {
+		t[i].TitleRu = t[i].Title
+		t[i].TitleEn = titlesEn[t[i].Name]
+	}
+	return &t, nil
+}
+
+// getBilingual calls fn for every language of bilingualLangs concurrently,
+// the rest of the requests are canceled on the first error
+func (c *Client) getBilingual(ctx context.Context, fn func(ctx context.Context, i int) error) error {
+	if ctx == nil {
+		return ErrNonNilContext
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(bilingualLangs))
+	c.runBatch(ctx, len(bilingualLangs), func(ctx context.Context, i int) {
+		errs[i] = fn(ctx, i)
+		if errs[i] != nil {
+			cancel()
+		}
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+	return firstCauseError(errs)
+}
+
+// mergeIndexTitles fills the Russian titles of the entries of ru
+// and the English ones from the entries of en with the same keys
+func mergeIndexTitles(ru *Index, en *Index) {
+	titles := make(map[string]string)
+	for _, e := range en.Engines {
+		titles[titleKey(keyEngines, e.Id)] = e.Title
+	}
+	for _, m := range en.Markets {
+		titles[titleKey(keyMarkets, m.Id)] = m.Title
+	}
+	for _, b := range en.Boards {
+		titles[titleKey(keyBoards, b.Id)] = b.BoardTitle
+	}
+	for _, bg := range en.BoardGroups {
+		titles[titleKey(keyBoardGroups, bg.Id)] = bg.Title
+	}
+	for _, d := range en.Durations {
+		titles[titleKey(keyDurations, d.Interval)] = d.Title
+		titles[titleKey(keyDurations, d.Interval)+"/hint"] = d.Hint
+	}
+	for _, st := range en.SecurityTypes {
+		titles[titleKey(keySecurityTypes, st.Id)] = st.Title
+	}
+	for _, sg := range en.SecurityGroups {
+		titles[titleKey(keySecurityGroups, sg.Id)] = sg.Title
+	}
+	for _, sc := range en.SecurityCollections {
+		titles[titleKey(keySecurityCollections, sc.Id)] = sc.Title
+	}
+
+	setTitles := func(g *GeneralFields, table string) {
+		g.TitleRu = g.Title
+		g.TitleEn = titles[titleKey(table, g.Id)]
+	}
+	for i := range ru.Engines {
+		setTitles(&ru.Engines[i].GeneralFields, keyEngines)
+	}
+	for i := range ru.Markets {
+		setTitles(&ru.Markets[i].GeneralFields, keyMarkets)
+	}
+	for i := range ru.Boards {
+		b := &ru.Boards[i]
+		b.BoardTitleRu = b.BoardTitle
+		b.BoardTitleEn = titles[titleKey(keyBoards, b.Id)]
+	}
+	for i := range ru.BoardGroups {
+		setTitles(&ru.BoardGroups[i].GeneralFields, keyBoardGroups)
+	}
+	for i := range ru.Durations {
+		d := &ru.Durations[i]
+		d.TitleRu, d.HintRu = d.Title, d.Hint
+		d.TitleEn = titles[titleKey(keyDurations, d.Interval)]
+		d.HintEn = titles[titleKey(keyDurations, d.Interval)+"/hint"]
+	}
+	for i := range ru.SecurityTypes {
+		setTitles(&ru.SecurityTypes[i].GeneralFields, keySecurityTypes)
+	}
+	for i := range ru.SecurityGroups {
+		setTitles(&ru.SecurityGroups[i].GeneralFields, keySecurityGroups)
+	}
+	for i := range ru.SecurityCollections {
+		setTitles(&ru.SecurityCollections[i].GeneralFields, keySecurityCollections)
+	}
+}
+
+func titleKey(table string, id int64) string {
+	return table + "/" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,145 @@
+package moexiss
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// getBilingualTestingSrv emulates an external server which answers by the language of the request,
+// byLang returns the response for the value of the query parameter
+func getBilingualTestingSrv(param string, byLang func(lang string) []byte) (*Client, *int32, func()) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(byLang(r.URL.Query().Get(param)))
+	}))
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	return c, &requests, srv.Close
+}
+
+func TestIndexService_ListBilingual(t *testing.T) {
+	c, requests, closeSrv := getBilingualTestingSrv("engines.lang", func(lang string) []byte {
+		index := getTestingIndex(t)
+		if lang == LangEn.String() {
+			for i := range index.Engines {
+				index.Engines[i].Title = "en " + index.Engines[i].Name
+			}
+			for i := range index.Boards {
+				index.Boards[i].BoardTitle = "en " + index.Boards[i].BoardId
+			}
+			for i := range index.Durations {
+				index.Durations[i].Hint = "en hint"
+			}
+		}
+		var b bytes.Buffer
+		_ = WriteIndexSnapshot(&b, index)
+		return b.Bytes()
+	})
+	defer closeSrv()
+
+	index, err := c.Index.ListBilingual(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := atomic.LoadInt32(requests), int32(2); got != expected {
+		t.Fatalf("Error: expecting %d requests \ngot %d \ninstead", expected, got)
+	}
+	ref, _ := NewReference(index)
+	board, _ := ref.BoardByID("TQBR")
+	if board.BoardTitleRu != "Т+: Акции и ДР - безадрес." || board.BoardTitleEn != "en TQBR" || board.BoardTitle != board.BoardTitleRu {
+		t.Fatalf("Error: expecting bilingual titles of TQBR \ngot %q, %q, %q \ninstead", board.BoardTitle, board.BoardTitleRu, board.BoardTitleEn)
+	}
+	engine, _ := ref.EngineOf(Market{Engine: Engine{GeneralFields{Id: board.EngineId}}})
+	if engine.TitleRu != "Фондовый рынок и рынок депозитов" || engine.TitleEn != "en stock" {
+		t.Fatalf("Error: expecting bilingual titles of the engine \ngot %q, %q \ninstead", engine.TitleRu, engine.TitleEn)
+	}
+	if d := index.Durations[0]; d.HintEn != "en hint" || d.HintRu == "" || d.TitleEn != d.TitleRu {
+		t.Fatalf("Error: expecting bilingual hints of the duration \ngot %v \ninstead", d)
+	}
+}
+
+func TestAggregateService_GetAggregatesBilingual(t *testing.T) {
+	c, requests, closeSrv := getBilingualTestingSrv("lang", func(lang string) []byte {
+		b, _ := getTestingData("aggregates.json")
+		if lang == LangEn.String() {
+			b = bytes.Replace(b, []byte("Рынок акций"), []byte("Equities"), 1)
+		}
+		return b
+	})
+	defer closeSrv()
+
+	ar, err := c.Aggregates.GetAggregatesBilingual(context.Background(), "SBERP", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := atomic.LoadInt32(requests), int32(2); got != expected {
+		t.Fatalf("Error: expecting %d requests \ngot %d \ninstead", expected, got)
+	}
+	if ag := ar.Aggregates[0]; ag.MarketTitleRu != "Рынок акций" || ag.MarketTitleEn != "Equities" || ag.MarketTitle != ag.MarketTitleRu {
+		t.Fatalf("Error: expecting bilingual market titles \ngot %q, %q, %q \ninstead", ag.MarketTitle, ag.MarketTitleRu, ag.MarketTitleEn)
+	}
+	if got, expected := ar.SecurityId, "SBERP"; got != expected {
+		t.Fatalf("Error: expecting security %s \ngot %s \ninstead", expected, got)
+	}
+}
+
+func TestAggregateService_GetAggregatesBilingualBadSecurity(t *testing.T) {
+	c, requests, closeSrv := getBilingualTestingSrv("lang", func(string) []byte { return nil })
+	defer closeSrv()
+
+	if _, got := c.Aggregates.GetAggregatesBilingual(context.Background(), "sb", nil); got != ErrBadSecurityParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadSecurityParameter, got)
+	}
+	if got := atomic.LoadInt32(requests); got != 0 {
+		t.Fatalf("Error: expecting no requests \ngot %d \ninstead", got)
+	}
+}
+
+func TestTurnoverService_GetTurnoversBilingual(t *testing.T) {
+	c, _, closeSrv := getBilingualTestingSrv("lang", func(lang string) []byte {
+		b, _ := getTestingData("turnover.json")
+		if lang == LangRu.String() {
+			b = bytes.Replace(b, []byte("Securities Market"), []byte("Фондовый рынок"), 1)
+		}
+		return b
+	})
+	defer closeSrv()
+
+	turnovers, err := c.Turnovers.GetTurnoversBilingual(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if tr := (*turnovers)[0]; tr.TitleRu != "Фондовый рынок" || tr.TitleEn != "Securities Market" || tr.Title != tr.TitleRu {
+		t.Fatalf("Error: expecting bilingual titles \ngot %q, %q, %q \ninstead", tr.Title, tr.TitleRu, tr.TitleEn)
+	}
+}
+
+func TestGetBilingualError(t *testing.T) {
+	c, _, closeSrv := getBilingualTestingSrv("lang", func(lang string) []byte {
+		if lang == LangEn.String() {
+			return []byte("{")
+		}
+		b, _ := getTestingData("turnover.json")
+		return b
+	})
+	defer closeSrv()
+
+	if _, err := c.Turnovers.GetTurnoversBilingual(context.Background(), nil); err == nil {
+		t.Fatalf("Error: expecting an error of the English request")
+	}
+	var ctx context.Context = nil
+	if _, got := c.Turnovers.GetTurnoversBilingual(ctx, nil); got != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, got)
+	}
+	if _, got := c.Index.ListBilingual(context.Background(), NewIndexReqOptionsBuilder().Engine().Lang("de").Build()); !strings.Contains(got.Error(), "engines.lang") {
+		t.Fatalf("Error: expecting an invalid option error \ngot %v \ninstead", got)
+	}
+}
@@ -0,0 +1,221 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path"
+	"time"
+)
+
+// Capitalization struct represents the market capitalization of the stock market for a date
+type Capitalization struct {
+	TradeDate time.Time // "TRADEDATE" in MoscowLocation
+	Value     float64   // "ISSUECAPITALIZATION" RUB
+	ValueUsd  float64   // "ISSUECAPITALIZATION_USD" USD, it's 0 if MoEx ISS API doesn't provide it
+}
+
+// QuotedSecuritiesTotal struct represents the number of quoted securities of the stock market for a date
+type QuotedSecuritiesTotal struct {
+	TradeDate time.Time // "tradedate" in MoscowLocation, the date of the request if there is no such key
+	Total     int64     // the number of quoted securities
+}
+
+const (
+	capitalizationPartOfPath   = "capitalization"
+	quotedSecuritiesPartOfPath = "quotedsecurities"
+	capitalizationExtension    = ".json"
+
+	capitalizationEndpoint   = "statistics/engines/{engine}/capitalization"
+	quotedSecuritiesEndpoint = "statistics/engines/{engine}/quotedsecurities"
+
+	// quotedSecuritiesRangeMaxDays limits the number of dates of GetQuotedSecuritiesTotals
+	quotedSecuritiesRangeMaxDays = 366
+
+	capitalizationKeyCapitalization   = "capitalization"
+	capitalizationKeyTradeDate        = "TRADEDATE"
+	capitalizationKeyValue            = "ISSUECAPITALIZATION"
+	capitalizationKeyValueUsd         = "ISSUECAPITALIZATION_USD"
+	capitalizationKeyQuotedSecurities = "quotedsecurities"
+	capitalizationKeyQuotedTradeDate  = "tradedate"
+)
+
+// CapitalizationService gets the market capitalization and the number of quoted securities
+// of the stock market from the MoEx ISS API.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/statistics/engines/stock/capitalization
+// https://iss.moex.com/iss/statistics/engines/stock/quotedsecurities
+type CapitalizationService service
+
+// GetCapitalization provides the market capitalization for the date(see DateRangeReqOptionsBuilder.Date)
+// or for the period(see DateRangeReqOptionsBuilder.From and DateRangeReqOptionsBuilder.Till),
+// all the pages beginning with DateRangeReqOptionsBuilder.Start are requested.
+// It returns ErrTooManyPages if there are more than cursorMaxPages pages.
+func (c *CapitalizationService) GetCapitalization(ctx context.Context, opt *DateRangeRequestOptions) ([]Capitalization, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	pageOpt := DateRangeRequestOptions{}
+	if opt != nil {
+		pageOpt = *opt
+	}
+	caps := make([]Capitalization, 0)
+	err := getAllPages(pageOpt.start, cursorMaxPages, func(start uint64) (*Cursor, error) {
+		pageOpt.start = start
+		b, err := c.get(ctx, capitalizationEndpoint, capitalizationPartOfPath, &pageOpt)
+		if err != nil {
+			return nil, err
+		}
+		var cursor *Cursor
+		err = parseBlockWithCursor(b, capitalizationKeyCapitalization, &cursor, func(data []byte) error {
+			cp := Capitalization{}
+			errParse := parseCapitalization(data, &cp)
+			if errParse == nil {
+				caps = append(caps, cp)
+			}
+			return errParse
+		})
+		if err != nil {
+			return nil, err
+		}
+		return cursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return caps, nil
+}
+
+// GetQuotedSecuritiesTotals provides the numbers of quoted securities by dates.
+// It's the number for the date(see DateRangeReqOptionsBuilder.Date), for the last available date by default,
+// or the numbers for every date of the period(see DateRangeReqOptionsBuilder.From and DateRangeReqOptionsBuilder.Till),
+// 'till' is today if it's zero. Every date of the period is a separate request,
+// so it returns ErrBadDateRangeParameter if the period is longer than quotedSecuritiesRangeMaxDays.
+// Dates of the period without quoted securities(e.g. weekends) are skipped.
+func (c *CapitalizationService) GetQuotedSecuritiesTotals(ctx context.Context, opt *DateRangeRequestOptions) ([]QuotedSecuritiesTotal, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if opt == nil || (opt.from.IsZero() && opt.till.IsZero()) {
+		qst, err := c.getQuotedSecuritiesTotal(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		return []QuotedSecuritiesTotal{*qst}, nil
+	}
+	till := opt.till
+	if till.IsZero() {
+		till = time.Now().In(MoscowLocation)
+	}
+	dates, err := rangeDates(opt.from, till, quotedSecuritiesRangeMaxDays)
+	if err != nil {
+		return nil, err
+	}
+	dateOpt := DateRangeRequestOptions{lang: opt.lang}
+	series := make([]QuotedSecuritiesTotal, 0, len(dates))
+	for _, date := range dates {
+		dateOpt.date = date
+		qst, err := c.getQuotedSecuritiesTotal(ctx, &dateOpt)
+		if err != nil {
+			return nil, err
+		}
+		if qst.Total == 0 {
+			continue
+		}
+		series = append(series, *qst)
+	}
+	return series, nil
+}
+
+// getQuotedSecuritiesTotal provides the number of quoted securities for the date of the options
+func (c *CapitalizationService) getQuotedSecuritiesTotal(ctx context.Context, opt *DateRangeRequestOptions) (*QuotedSecuritiesTotal, error) {
+	b, err := c.get(ctx, quotedSecuritiesEndpoint, quotedSecuritiesPartOfPath, opt)
+	if err != nil {
+		return nil, err
+	}
+	qst := QuotedSecuritiesTotal{}
+	if opt != nil {
+		qst.TradeDate = opt.date
+	}
+	var cursor *Cursor
+	var rows int64
+	err = parseBlockWithCursor(b, capitalizationKeyQuotedSecurities, &cursor, func(data []byte) error {
+		rows++
+		if rows > 1 || !hasKey(data, capitalizationKeyQuotedTradeDate) {
+			return nil
+		}
+		tradeDate, errParse := parseStringWithDefaultValueByKey(data, capitalizationKeyQuotedTradeDate, "")
+		if errParse != nil {
+			return errParse
+		}
+		qst.TradeDate, errParse = parseDateTimeWithDefaultValue(tradeDate, "")
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	qst.Total = rows
+	// the cursor counts the securities of all the pages
+	if cursor != nil {
+		qst.Total = cursor.Total
+	}
+	return &qst, nil
+}
+
+func (c *CapitalizationService) get(ctx context.Context, endpoint string, fileName string, opt *DateRangeRequestOptions) ([]byte, error) {
+	req, err := c.client.newEndpointRequest(endpoint, c.getUrl(fileName, opt))
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = c.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// getUrl provides an url for a request of the capitalization or of the quoted securities
+// opt *DateRangeRequestOptions can be nil, it is safe
+func (c *CapitalizationService) getUrl(fileName string, opt *DateRangeRequestOptions) string {
+	url, _ := c.client.BaseURL.Parse(statisticsPartOfPath)
+	url.Path = path.Join(url.Path, enginePartOfPath, EngineStock.String(), fileName)
+	url.Path += capitalizationExtension
+	gotURL := addDateRangeRequestOptions(url, opt)
+	return gotURL.String()
+}
+
+func parseCapitalization(data []byte, cp *Capitalization) (err error) {
+	tradeDate, err := parseStringWithDefaultValueByKey(data, capitalizationKeyTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	value, err := parseFloatWithDefaultValue(data, capitalizationKeyValue)
+	if err != nil {
+		return
+	}
+
+	var valueUsd float64
+	if hasKey(data, capitalizationKeyValueUsd) {
+		valueUsd, err = parseFloatWithDefaultValue(data, capitalizationKeyValueUsd)
+		if err != nil {
+			return
+		}
+	}
+
+	date, err := parseDateTimeWithDefaultValue(tradeDate, "")
+	if err != nil {
+		return
+	}
+
+	cp.TradeDate = date
+	cp.Value = value
+	cp.ValueUsd = valueUsd
+
+	return
+}
@@ -0,0 +1,243 @@
+package moexiss
+
+import (
+	"context"
+	"fmt"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getCapitalizationSrv(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fileName := "capitalization.json"
+		if strings.HasSuffix(r.URL.Path, "/quotedsecurities.json") {
+			fileName = "quotedsecurities.json"
+		}
+		byteValue, err := getTestingData(fileName)
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+}
+
+func TestCapitalizationGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	opt := NewDateRangeReqOptionsBuilder().
+		From(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)).
+		Till(time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC)).
+		Build()
+	type Case struct {
+		fileName string
+		opt      *DateRangeRequestOptions
+		expected string
+	}
+	cases := []Case{
+		{capitalizationPartOfPath, nil, `https://iss.moex.com/iss/statistics/engines/stock/capitalization.json?iss.json=extended&iss.meta=off`},
+		{capitalizationPartOfPath, opt, `https://iss.moex.com/iss/statistics/engines/stock/capitalization.json?from=2023-12-01&iss.json=extended&iss.meta=off&till=2023-12-29`},
+		{quotedSecuritiesPartOfPath, nil, `https://iss.moex.com/iss/statistics/engines/stock/quotedsecurities.json?iss.json=extended&iss.meta=off`},
+	}
+	for i, c1 := range cases {
+		if got := c.Capitalization.getUrl(c1.fileName, c1.opt); got != c1.expected {
+			t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead in %d case", c1.expected, got, i)
+		}
+	}
+}
+
+func TestParseCapitalization(t *testing.T) {
+	expectedStruct := Capitalization{
+		TradeDate: time.Date(2023, 12, 28, 0, 0, 0, 0, MoscowLocation),
+		Value:     62987210045871.2,
+	}
+	var incomeJSON = `
+{"TRADEDATE": "2023-12-28", "ISSUECAPITALIZATION": 62987210045871.2, "ISSUECAPITALIZATION_USD": null}
+`
+	cp := Capitalization{}
+	if err := parseCapitalization([]byte(incomeJSON), &cp); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := cp, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseCapitalizationErrCases(t *testing.T) {
+	if got, expected := parseCapitalization([]byte(`{"TRADEDATE": "2023-12-28"}`), &Capitalization{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if err := parseCapitalization([]byte(`{"TRADEDATE": "28.12.2023", "ISSUECAPITALIZATION": 1}`), &Capitalization{}); err == nil {
+		t.Fatalf("Error: expecting error \ngot <nil> \ninstead")
+	}
+}
+
+func TestCapitalizationService_GetCapitalization(t *testing.T) {
+	srv := getCapitalizationSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	caps, err := c.Capitalization.GetCapitalization(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(caps), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := caps[3].ValueUsd, 699512030218.4; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCapitalizationService_GetCapitalizationPages(t *testing.T) {
+	starts := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := r.URL.Query().Get("start")
+		starts = append(starts, start)
+		index := 0
+		if start != "" {
+			_, _ = fmt.Sscan(start, &index)
+		}
+		_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"capitalization": [{"TRADEDATE": "2023-12-%02d", "ISSUECAPITALIZATION": 1}], "capitalization.cursor": [{"INDEX": %d, "TOTAL": 2, "PAGESIZE": 1}]}]`,
+			index+1, index)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	caps, err := c.Capitalization.GetCapitalization(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(caps), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := fmt.Sprint(starts), "[ 1]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCapitalizationService_GetCapitalizationStartIgnored(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"capitalization": [{"TRADEDATE": "2023-12-01", "ISSUECAPITALIZATION": 1}], "capitalization.cursor": [{"INDEX": 0, "TOTAL": 2, "PAGESIZE": 1}]}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.Capitalization.GetCapitalization(context.Background(), nil); err != ErrCursorNotAdvanced {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrCursorNotAdvanced, err)
+	}
+	if got, expected := requests, 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v requests\ngot:\n %v requests\ninstead", expected, got)
+	}
+}
+
+func TestCapitalizationService_GetQuotedSecuritiesTotals(t *testing.T) {
+	srv := getCapitalizationSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	date := time.Date(2023, 12, 29, 0, 0, 0, 0, MoscowLocation)
+	opt := NewDateRangeReqOptionsBuilder().Date(date).Build()
+	totals, err := c.Capitalization.GetQuotedSecuritiesTotals(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := fmt.Sprint(totals), fmt.Sprint([]QuotedSecuritiesTotal{{TradeDate: date, Total: 1342}}); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCapitalizationService_GetQuotedSecuritiesTotalsRange(t *testing.T) {
+	dates := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		dates = append(dates, date)
+		if date == "2023-12-30" {
+			_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"quotedsecurities": [], "quotedsecurities.cursor": [{"INDEX": 0, "TOTAL": 0, "PAGESIZE": 100}]}]`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"quotedsecurities": [{"secid": "AFLT", "tradedate": "%s"}], "quotedsecurities.cursor": [{"INDEX": 0, "TOTAL": 1342, "PAGESIZE": 1}]}]`, date)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	opt := NewDateRangeReqOptionsBuilder().
+		From(time.Date(2023, 12, 29, 0, 0, 0, 0, MoscowLocation)).
+		Till(time.Date(2023, 12, 31, 0, 0, 0, 0, MoscowLocation)).
+		Build()
+	totals, err := c.Capitalization.GetQuotedSecuritiesTotals(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := fmt.Sprint(dates), "[2023-12-29 2023-12-30 2023-12-31]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := len(totals), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := totals[1].TradeDate, time.Date(2023, 12, 31, 0, 0, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCapitalizationService_GetQuotedSecuritiesTotalsBadRange(t *testing.T) {
+	c := NewClient(nil)
+	till := time.Date(2023, 12, 31, 0, 0, 0, 0, MoscowLocation)
+	opt := NewDateRangeReqOptionsBuilder().From(till.AddDate(0, 0, -quotedSecuritiesRangeMaxDays)).Till(till).Build()
+	if _, err := c.Capitalization.GetQuotedSecuritiesTotals(context.Background(), opt); err != ErrBadDateRangeParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadDateRangeParameter, err)
+	}
+	opt = NewDateRangeReqOptionsBuilder().Till(till).Build()
+	if _, err := c.Capitalization.GetQuotedSecuritiesTotals(context.Background(), opt); err != ErrBadDateRangeParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadDateRangeParameter, err)
+	}
+}
+
+func TestCapitalizationService_GetQuotedSecuritiesTotalsNoCursor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"quotedsecurities": [{"secid": "AFLT"}, {"secid": "GAZP"}]}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	totals, err := c.Capitalization.GetQuotedSecuritiesTotals(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := totals[0].Total, int64(2); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCapitalizationService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.Capitalization.GetCapitalization(context.Background(), nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+	if _, err := c.Capitalization.GetQuotedSecuritiesTotals(context.Background(), nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestCapitalizationNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	if _, err := c.Capitalization.GetCapitalization(ctx, nil); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/dimakoz/moexiss"
+	"github.com/dimakoz/moexiss/export"
+	"io"
+	"strings"
+)
+
+// command represents a subcommand of moexiss
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{"index", "index [-table name] [-lang ru|en]", runIndex},
+	{"securities", "securities", runSecurities},
+	{"turnovers", "turnovers [-lang ru|en] [-date YYYY-MM-DD] [-tonight]", runTurnovers},
+	{"aggregates", "aggregates <secid> [-lang ru|en] [-date YYYY-MM-DD]", runAggregates},
+	{"indices", "indices <secid> [-lang ru|en]", runIndices},
+	{"listing", "listing <engine> <market> [-board id|-boardgroup id] [-status all|traded|nottraded] [-start n] [-lang ru|en]", runListing},
+	{"secstats", "secstats <engine> <market> [-ticker id]... [-board id]... [-session main|additional|total]", runSecStats},
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// indexTableNames contains sub-tables of moexiss.Index available for 'index' command
+var indexTableNames = []string{
+	"engines",
+	"markets",
+	"boards",
+	"boardgroups",
+	"durations",
+	"securitytypes",
+	"securitygroups",
+	"securitycollections",
+}
+
+// indexTable returns the sub-table of moexiss.Index by its name
+func indexTable(i *moexiss.Index, name string) export.Table {
+	switch name {
+	case "markets":
+		return export.Markets(i.Markets)
+	case "boards":
+		return export.Boards(i.Boards)
+	case "boardgroups":
+		return export.BoardGroups(i.BoardGroups)
+	case "durations":
+		return export.Durations(i.Durations)
+	case "securitytypes":
+		return export.SecurityTypes(i.SecurityTypes)
+	case "securitygroups":
+		return export.SecurityGroups(i.SecurityGroups)
+	case "securitycollections":
+		return export.SecurityCollections(i.SecurityCollections)
+	default:
+		return export.Engines(i.Engines)
+	}
+}
+
+func runIndex(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, cf := newFlagSet("index", stderr, true, false)
+	tableName := fs.String("table", "engines", "sub-table of the reference: "+strings.Join(indexTableNames, ", "))
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 0 {
+		return errUsage
+	}
+	if err = cf.validate(); err != nil {
+		return err
+	}
+	if !containsString(indexTableNames, *tableName) {
+		return fmt.Errorf("unknown table %q", *tableName)
+	}
+	c, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	lang, _ := cf.language()
+	bld := moexiss.NewIndexReqOptionsBuilder()
+	bld.Engine().Lang(lang)
+	bld.Market().Lang(lang)
+	bld.Board().Lang(lang)
+	bld.BoardGroup().Lang(lang)
+	bld.Duration().Lang(lang)
+	bld.SecurityType().Lang(lang)
+	bld.SecurityGroup().Lang(lang)
+	bld.SecurityCollection().Lang(lang)
+	index, err := c.Index.List(ctx, bld.Build())
+	if err != nil {
+		return err
+	}
+	return writeResult(stdout, cf.format, indexTable(index, *tableName))
+}
+
+func runSecurities(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, cf := newFlagSet("securities", stderr, false, false)
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 0 {
+		return errUsage
+	}
+	if err = cf.validate(); err != nil {
+		return err
+	}
+	c, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	securities, err := c.Securities.List(ctx)
+	if err != nil {
+		return err
+	}
+	return writeResult(stdout, cf.format, export.Securities(*securities))
+}
+
+func runTurnovers(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, cf := newFlagSet("turnovers", stderr, true, true)
+	tonight := fs.Bool("tonight", false, "show turnovers of the evening session")
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 0 {
+		return errUsage
+	}
+	if err = cf.validate(); err != nil {
+		return err
+	}
+	c, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	lang, _ := cf.language()
+	date, _ := cf.parsedDate()
+	opt := moexiss.NewTurnoverReqOptionsBuilder().
+		Lang(lang).
+		Date(date).
+		IsTonightSession(*tonight).
+		Build()
+	turnovers, err := c.Turnovers.GetTurnovers(ctx, opt)
+	if err != nil {
+		return err
+	}
+	return writeResult(stdout, cf.format, export.Turnovers(*turnovers))
+}
+
+func runAggregates(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, cf := newFlagSet("aggregates", stderr, true, true)
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 1 {
+		return errUsage
+	}
+	if err = cf.validate(); err != nil {
+		return err
+	}
+	c, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	lang, _ := cf.language()
+	date, _ := cf.parsedDate()
+	opt := moexiss.NewAggregateReqOptionsBuilder().
+		Lang(lang).
+		Date(date).
+		Build()
+	result, err := c.Aggregates.GetAggregates(ctx, positional[0], opt)
+	if err != nil {
+		return err
+	}
+	return writeResult(stdout, cf.format, export.Aggregates(result.Aggregates))
+}
+
+func runIndices(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, cf := newFlagSet("indices", stderr, true, false)
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 1 {
+		return errUsage
+	}
+	if err = cf.validate(); err != nil {
+		return err
+	}
+	c, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	lang, _ := cf.language()
+	opt := moexiss.NewIndicesReqOptionsBuilder().
+		Lang(lang).
+		Build()
+	result, err := c.Indices.GetIndices(ctx, positional[0], opt)
+	if err != nil {
+		return err
+	}
+	return writeResult(stdout, cf.format, export.Indices(result.Indices))
+}
+
+func runListing(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, cf := newFlagSet("listing", stderr, true, false)
+	board := fs.String("board", "", "board of the listing")
+	boardGroup := fs.String("boardgroup", "", "board group of the listing")
+	status := fs.String("status", "", "trading status: all, traded or nottraded")
+	start := fs.Uint64("start", 0, "row number to begin the result with")
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 2 {
+		return errUsage
+	}
+	if *board != "" && *boardGroup != "" {
+		return fmt.Errorf("-board and -boardgroup can't be used together")
+	}
+	if err = cf.validate(); err != nil {
+		return err
+	}
+	trStatus := moexiss.HistoryListingTradingStatus(*status)
+	switch trStatus {
+	case moexiss.ListingTradingStatusUndefined, moexiss.ListingTradingStatusAll,
+		moexiss.ListingTradingStatusTraded, moexiss.ListingTradingStatusNotTraded:
+	default:
+		return fmt.Errorf("unknown status %q", *status)
+	}
+	c, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	lang, _ := cf.language()
+	opt := moexiss.NewHistoryListingReqOptionsBuilder().
+		Lang(lang).
+		Status(trStatus).
+		Start(*start).
+		Build()
+	engine, market := moexiss.EngineName(positional[0]), positional[1]
+	var result *moexiss.ListingResponse
+	switch {
+	case *board != "":
+		result, err = c.HistoryListing.GetListingByBoard(ctx, engine, market, *board, opt)
+	case *boardGroup != "":
+		result, err = c.HistoryListing.GetListingByBoardGroup(ctx, engine, market, *boardGroup, opt)
+	default:
+		result, err = c.HistoryListing.GetListing(ctx, engine, market, opt)
+	}
+	if err != nil {
+		return err
+	}
+	return writeResult(stdout, cf.format, export.Listings(result.Listing))
+}
+
+// tradingSessions contains possible values of 'session' flag of 'secstats' command
+var tradingSessions = map[string]moexiss.TradingSession{
+	"":           moexiss.TradingSessionUndefined,
+	"main":       moexiss.TradingSessionMain,
+	"additional": moexiss.TradingSessionAdditional,
+	"total":      moexiss.TradingSessionTotal,
+}
+
+func runSecStats(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, cf := newFlagSet("secstats", stderr, false, false)
+	var tickers, boards listFlag
+	fs.Var(&tickers, "ticker", "ticker of the security, can be repeated or comma separated")
+	fs.Var(&boards, "board", "board, can be repeated or comma separated")
+	session := fs.String("session", "", "trading session: main, additional or total")
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) != 2 {
+		return errUsage
+	}
+	if err = cf.validate(); err != nil {
+		return err
+	}
+	trSession, ok := tradingSessions[*session]
+	if !ok {
+		return fmt.Errorf("unknown trading session %q", *session)
+	}
+	c, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	bld := moexiss.NewStatReqOptionsBuilder().TypeTradingSession(trSession)
+	for _, ticker := range tickers {
+		bld.AddTicker(ticker)
+	}
+	for _, board := range boards {
+		bld.AddBoard(board)
+	}
+	result, err := c.Stats.GetSecStats(ctx, moexiss.EngineName(positional[0]), positional[1], bld.Build())
+	if err != nil {
+		return err
+	}
+	return writeResult(stdout, cf.format, export.SecStats(result.SecStats))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
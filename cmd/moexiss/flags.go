@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dimakoz/moexiss"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// errUsage is returned by commands when they are called with wrong arguments
+var errUsage = errors.New("wrong usage")
+
+// A section of output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// commonFlags contains flags shared by the commands
+type commonFlags struct {
+	format  string
+	baseURL string
+	lang    string
+	date    string
+
+	command  string
+	withLang bool // the request of the command supports 'lang' flag
+	withDate bool // the request of the command supports 'date' flag
+}
+
+// newFlagSet creates a flag.FlagSet of a command with the common flags.
+// 'lang' and 'date' flags are registered for every command,
+// validate returns a usage error if the request of the command doesn't support them
+func newFlagSet(name string, stderr io.Writer, withLang, withDate bool) (*flag.FlagSet, *commonFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	cf := &commonFlags{command: name, withLang: withLang, withDate: withDate}
+	fs.StringVar(&cf.format, "format", formatTable, "output format: table, json or csv")
+	fs.StringVar(&cf.baseURL, "base-url", "", "base URL of MoEx ISS API")
+	fs.StringVar(&cf.lang, "lang", "", "language of the result: ru or en, if the command supports it")
+	fs.StringVar(&cf.date, "date", "", "date of the result in YYYY-MM-DD format, if the command supports it")
+	return fs, cf
+}
+
+// parseArgs parses flags which can be placed before, after or between positional arguments
+// and returns the positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// validate checks the common flags before any network call
+func (cf *commonFlags) validate() error {
+	if cf.lang != "" && !cf.withLang {
+		return fmt.Errorf("%w: -lang isn't supported by %s", errUsage, cf.command)
+	}
+	if cf.date != "" && !cf.withDate {
+		return fmt.Errorf("%w: -date isn't supported by %s", errUsage, cf.command)
+	}
+	switch cf.format {
+	case formatTable, formatJSON, formatCSV:
+	default:
+		return fmt.Errorf("unknown output format %q", cf.format)
+	}
+	if _, err := cf.language(); err != nil {
+		return err
+	}
+	if _, err := cf.parsedDate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// newClient creates moexiss.Client using 'base-url' flag
+func (cf *commonFlags) newClient() (*moexiss.Client, error) {
+	c := moexiss.NewClient(nil)
+	if cf.baseURL == "" {
+		return c, nil
+	}
+	baseURL := cf.baseURL
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	c.BaseURL = u
+	return c, nil
+}
+
+// language returns moexiss.Language from 'lang' flag
+func (cf *commonFlags) language() (moexiss.Language, error) {
+	switch cf.lang {
+	case "":
+		return moexiss.LangUndefined, nil
+	case moexiss.LangRu.String():
+		return moexiss.LangRu, nil
+	case moexiss.LangEn.String():
+		return moexiss.LangEn, nil
+	default:
+		return moexiss.LangUndefined, fmt.Errorf("unknown language %q", cf.lang)
+	}
+}
+
+// parsedDate returns time.Time from 'date' flag, zero time.Time if the flag is empty
+func (cf *commonFlags) parsedDate() (time.Time, error) {
+	if cf.date == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse("2006-01-02", cf.date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bad date %q: expecting YYYY-MM-DD", cf.date)
+	}
+	return date, nil
+}
+
+// listFlag represents a flag which can be repeated or contain comma separated values
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Command moexiss is a command-line client of MoEx ISS API.
+//
+// Usage:
+//
+//	moexiss <command> [arguments] [flags]
+//
+// The commands are:
+//
+//	index                                  global ISS reference
+//	securities                             list of securities
+//	turnovers                              turnovers on all the markets
+//	aggregates <secid>                     aggregated trading results of the security
+//	indices <secid>                        indices that include the security
+//	listing <engine> <market>              listing of securities, see -board and -boardgroup
+//	secstats <engine> <market>             intermediate day summary, see -ticker, -board and -session
+//
+// Every command supports -format (table, json or csv) and -base-url flags,
+// all the formats use the column names of MoEx ISS API.
+// The -lang (ru or en) and -date (YYYY-MM-DD) flags are accepted by every command,
+// a command whose request doesn't support them exits with a usage error.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes a command and returns an exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 2
+	}
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "moexiss: unknown command %q\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+	err := cmd.run(ctx, args[1:], stdout, stderr)
+	if errors.Is(err, errUsage) {
+		if err != errUsage {
+			_, _ = fmt.Fprintf(stderr, "moexiss %s: %v\n", cmd.name, err)
+		}
+		_, _ = fmt.Fprintf(stderr, "usage: moexiss %s\n", cmd.usage)
+		return 2
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "moexiss %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage: moexiss <command> [arguments] [flags]")
+	_, _ = fmt.Fprintln(w, "\ncommands:")
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "  %s\n", cmd.usage)
+	}
+}
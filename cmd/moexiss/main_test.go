@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/dimakoz/moexiss/moexisstest"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	srv := moexisstest.NewServer()
+	defer srv.Close()
+
+	type Case struct {
+		args     []string
+		expected string
+	}
+	cases := []Case{
+		{[]string{"turnovers", "-base-url", srv.URL()}, "NAME"},
+		{[]string{"turnovers", "-base-url", srv.URL(), "-format", "csv", "-lang", "en"}, "stock,1,1988404.90786"},
+		{[]string{"index", "-base-url", srv.URL(), "-table", "markets", "-format", "json"}, "\"marketplace\":\"MXSE\""},
+		{[]string{"aggregates", "sberp", "-base-url", srv.URL(), "-date", "2022-01-19"}, "market_name"},
+		{[]string{"indices", "-base-url", srv.URL(), "sberp"}, "SHORTNAME"},
+		{[]string{"listing", "stock", "shares", "-base-url", srv.URL()}, "BNSBP"},
+		{[]string{"secstats", "stock", "shares", "-ticker", "GAZP,SBER", "-session", "main", "-base-url", srv.URL()}, "LCURRENTPRICE"},
+	}
+	for i, c := range cases {
+		var stdout, stderr bytes.Buffer
+		if got, expected := run(context.Background(), c.args, &stdout, &stderr), 0; got != expected {
+			t.Fatalf("Error: expecting exit code %d \ngot %d \ninstead in %d case: %s", expected, got, i, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), c.expected) {
+			t.Fatalf("Error: expecting output containing %q \ngot:\n%s \ninstead in %d case", c.expected, stdout.String(), i)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	type Case struct {
+		args     []string
+		expected int
+	}
+	cases := []Case{
+		{[]string{}, 2},
+		{[]string{"unknown"}, 2},
+		{[]string{"aggregates"}, 2},
+		{[]string{"listing", "stock"}, 2},
+		{[]string{"turnovers", "-unknown"}, 2},
+		{[]string{"turnovers", "-format", "xml"}, 1},
+		{[]string{"turnovers", "-lang", "de"}, 1},
+		{[]string{"turnovers", "-date", "24.02.2021"}, 1},
+		{[]string{"index", "-table", "unknown"}, 1},
+		{[]string{"listing", "stock", "shares", "-board", "TQBR", "-boardgroup", "57"}, 1},
+		{[]string{"listing", "stock", "shares", "-status", "unknown"}, 1},
+		{[]string{"secstats", "stock", "shares", "-session", "unknown"}, 1},
+		{[]string{"securities", "-lang", "en"}, 2},
+		{[]string{"secstats", "stock", "shares", "-date", "2021-02-24"}, 2},
+		{[]string{"listing", "stock", "shares", "-date", "2021-02-24"}, 2},
+		{[]string{"indices", "sberp", "-date", "2021-02-24"}, 2},
+	}
+	for i, c := range cases {
+		var stdout, stderr bytes.Buffer
+		if got, expected := run(context.Background(), c.args, &stdout, &stderr), c.expected; got != expected {
+			t.Fatalf("Error: expecting exit code %d \ngot %d \ninstead in %d case", expected, got, i)
+		}
+	}
+}
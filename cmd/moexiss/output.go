@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dimakoz/moexiss/export"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// writeResult writes the table in the given format,
+// all the formats share the columns of the table named by the keys of MoEx ISS API
+func writeResult(w io.Writer, format string, table export.Table) error {
+	switch format {
+	case formatJSON:
+		return export.WriteJSON(w, table)
+	case formatCSV:
+		return export.WriteCSV(w, table, nil)
+	default:
+		return writeTable(w, table)
+	}
+}
+
+// writeTable writes the table aligned by columns
+func writeTable(w io.Writer, table export.Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join(table.Columns(), "\t"))
+	cells := make([]string, len(table.Columns()))
+	for i := 0; i < table.Len(); i++ {
+		for k, value := range table.Row(i) {
+			cells[k] = export.FormatValue(value)
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
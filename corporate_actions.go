@@ -0,0 +1,327 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/url"
+	"path"
+	"time"
+)
+
+// Split struct represents a split or a reverse split of shares
+type Split struct {
+	Date       time.Time // "tradedate" in MoscowLocation
+	SecurityId string    // "secid"
+	Before     int64     // "before" Number of shares before the split
+	After      int64     // "after" Number of shares after the split
+}
+
+// Ratio returns the number of shares after the split per a share before it,
+// it's 0 if the split is malformed
+func (s Split) Ratio() float64 {
+	if s.Before <= 0 || s.After <= 0 {
+		return 0
+	}
+	return float64(s.After) / float64(s.Before)
+}
+
+// Changeover struct represents a change of a security id(ticker)
+type Changeover struct {
+	Date          time.Time // "action_date" in MoscowLocation
+	OldSecurityId string    // "old_secid"
+	NewSecurityId string    // "new_secid"
+}
+
+// DailyPrice struct represents daily prices of a security
+// which can be adjusted for splits and changeovers by AdjustPrices
+type DailyPrice struct {
+	SecurityId string
+	Date       time.Time
+	Open       float64
+	High       float64
+	Low        float64
+	Close      float64
+	Volume     float64 // Number of shares
+}
+
+const (
+	corpActionsSplitsPartOfPath     = "splits"
+	corpActionsSecuritiesPartOfPath = "securities"
+	corpActionsChangeoverPartOfPath = "changeover"
+	corpActionsSharesMarket         = "shares"
+	corpActionsFileExtension        = ".json"
+
+	splitsEndpoint         = "statistics/engines/{engine}/splits"
+	securitySplitsEndpoint = "statistics/engines/{engine}/splits/{security}"
+	changeoverEndpoint     = "history/engines/{engine}/markets/{market}/securities/changeover"
+
+	splitKeySplits    = "splits"
+	splitKeyTradeDate = "tradedate"
+	splitKeySecId     = "secid"
+	splitKeyBefore    = "before"
+	splitKeyAfter     = "after"
+
+	changeoverKeyChangeover = "changeover"
+	changeoverKeyActionDate = "action_date"
+	changeoverKeyOldSecId   = "old_secid"
+	changeoverKeyNewSecId   = "new_secid"
+)
+
+// CorporateActionsService gets splits of shares and changeovers of security ids
+// from the MoEx ISS API.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/statistics/engines/stock/splits
+// https://iss.moex.com/iss/statistics/engines/stock/splits/[security]
+// https://iss.moex.com/iss/history/engines/stock/markets/shares/securities/changeover
+type CorporateActionsService service
+
+// Splits provides splits of all the shares,
+// all the pages beginning with CorporateActionsReqOptionsBuilder.Start are requested.
+// It returns ErrTooManyPages if there are more than cursorMaxPages pages.
+func (ca *CorporateActionsService) Splits(ctx context.Context, opt *CorporateActionsRequestOptions) ([]Split, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return ca.getSplits(ctx, "", opt)
+}
+
+// SecuritySplits provides splits of the security
+func (ca *CorporateActionsService) SecuritySplits(ctx context.Context, security string, opt *CorporateActionsRequestOptions) ([]Split, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if !isOkSecurityParam(security) {
+		return nil, ErrBadSecurityParameter
+	}
+	return ca.getSplits(ctx, security, opt)
+}
+
+// Changeovers provides changeovers of security ids,
+// all the pages beginning with CorporateActionsReqOptionsBuilder.Start are requested.
+// It returns ErrTooManyPages if there are more than cursorMaxPages pages.
+func (ca *CorporateActionsService) Changeovers(ctx context.Context, opt *CorporateActionsRequestOptions) ([]Changeover, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	url, _ := ca.client.BaseURL.Parse(historyPartOfPath)
+	url.Path = path.Join(url.Path, enginePartOfPath, EngineStock.String(), marketsPartOfPath, corpActionsSharesMarket,
+		corpActionsSecuritiesPartOfPath, corpActionsChangeoverPartOfPath)
+	url.Path += corpActionsFileExtension
+
+	changeovers := make([]Changeover, 0)
+	err := ca.getAll(ctx, changeoverEndpoint, url, opt, changeoverKeyChangeover, func(data []byte) error {
+		c := Changeover{}
+		errParse := parseChangeover(data, &c)
+		if errParse == nil {
+			changeovers = append(changeovers, c)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changeovers, nil
+}
+
+func (ca *CorporateActionsService) getSplits(ctx context.Context, security string, opt *CorporateActionsRequestOptions) ([]Split, error) {
+	url, _ := ca.client.BaseURL.Parse(statisticsPartOfPath)
+	url.Path = path.Join(url.Path, enginePartOfPath, EngineStock.String(), corpActionsSplitsPartOfPath)
+	endpoint := splitsEndpoint
+	if security != "" {
+		url.Path = path.Join(url.Path, security)
+		endpoint = securitySplitsEndpoint
+	}
+	url.Path += corpActionsFileExtension
+
+	splits := make([]Split, 0)
+	err := ca.getAll(ctx, endpoint, url, opt, splitKeySplits, func(data []byte) error {
+		s := Split{}
+		errParse := parseSplit(data, &s)
+		if errParse == nil {
+			splits = append(splits, s)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+// getAll requests the 'block' block page by page following its cursor
+// and calls parseItem for every object of the block, see getAllPages
+func (ca *CorporateActionsService) getAll(ctx context.Context, endpoint string, url *url.URL, opt *CorporateActionsRequestOptions, block string, parseItem func(data []byte) error) error {
+	pageOpt := CorporateActionsRequestOptions{}
+	if opt != nil {
+		pageOpt = *opt
+	}
+	return getAllPages(pageOpt.start, cursorMaxPages, func(start uint64) (*Cursor, error) {
+		pageOpt.start = start
+		pageUrl := *url
+		req, err := ca.client.newEndpointRequest(endpoint, addCorporateActionsRequestOptions(&pageUrl, &pageOpt).String())
+		if err != nil {
+			return nil, err
+		}
+
+		var b bytes.Buffer
+		w := bufio.NewWriter(&b)
+
+		_, err = ca.client.Do(ctx, req, w)
+		if err != nil {
+			return nil, err
+		}
+		var cursor *Cursor
+		err = parseBlockWithCursor(b.Bytes(), block, &cursor, parseItem)
+		if err != nil {
+			return nil, err
+		}
+		return cursor, nil
+	})
+}
+
+// SecurityIdsOf returns the security id and its previous ids following the changeovers back in time,
+// the security id itself comes first
+func SecurityIdsOf(secId string, changeovers []Changeover) []string {
+	periods := securityIdPeriods(secId, changeovers)
+	ids := make([]string, 0, len(periods))
+	for _, p := range periods {
+		ids = append(ids, p.securityId)
+	}
+	return ids
+}
+
+// AdjustPrices returns prices of the security and of its previous ids following the changeovers.
+// The security id of the records is replaced by secId and the records before splits are adjusted:
+// prices are divided and volumes are multiplied by Split.Ratio.
+// Records of other securities and records of a previous id after its changeover are dropped,
+// the order of the records is kept.
+func AdjustPrices(secId string, prices []DailyPrice, splits []Split, changeovers []Changeover) []DailyPrice {
+	periods := make(map[string]time.Time)
+	for _, p := range securityIdPeriods(secId, changeovers) {
+		periods[p.securityId] = p.till
+	}
+	adjusted := make([]DailyPrice, 0, len(prices))
+	for _, p := range prices {
+		till, ok := periods[p.SecurityId]
+		if !ok || (!till.IsZero() && !p.Date.Before(till)) {
+			continue
+		}
+		factor := 1.0
+		for _, s := range splits {
+			if _, ok := periods[s.SecurityId]; !ok || s.Ratio() == 0 {
+				continue
+			}
+			if !sameDate(s.Date, p.Date) && s.Date.After(p.Date) {
+				factor *= s.Ratio()
+			}
+		}
+		p.SecurityId = secId
+		p.Open /= factor
+		p.High /= factor
+		p.Low /= factor
+		p.Close /= factor
+		p.Volume *= factor
+		adjusted = append(adjusted, p)
+	}
+	return adjusted
+}
+
+// securityIdPeriod represents a period of a security id which ends with a changeover,
+// 'till' is zero for the current security id
+type securityIdPeriod struct {
+	securityId string
+	till       time.Time
+}
+
+// securityIdPeriods returns periods of the security id and its previous ids
+// following the changeovers back in time
+func securityIdPeriods(secId string, changeovers []Changeover) []securityIdPeriod {
+	periods := []securityIdPeriod{{securityId: secId}}
+	seen := map[string]bool{secId: true}
+	current := periods[0]
+	for {
+		found := false
+		var latest Changeover
+		for _, c := range changeovers {
+			if c.NewSecurityId != current.securityId || c.OldSecurityId == current.securityId {
+				continue
+			}
+			if !current.till.IsZero() && c.Date.After(current.till) {
+				continue
+			}
+			if !found || c.Date.After(latest.Date) {
+				latest, found = c, true
+			}
+		}
+		if !found || seen[latest.OldSecurityId] {
+			return periods
+		}
+		seen[latest.OldSecurityId] = true
+		current = securityIdPeriod{securityId: latest.OldSecurityId, till: latest.Date}
+		periods = append(periods, current)
+	}
+}
+
+func parseSplit(data []byte, s *Split) (err error) {
+	tradeDate, err := parseStringWithDefaultValueByKey(data, splitKeyTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	secId, err := parseStringWithDefaultValueByKey(data, splitKeySecId, "")
+	if err != nil {
+		return
+	}
+
+	before, err := parseIntWithDefaultValue(data, splitKeyBefore)
+	if err != nil {
+		return
+	}
+
+	after, err := parseIntWithDefaultValue(data, splitKeyAfter)
+	if err != nil {
+		return
+	}
+
+	date, err := parseDateTimeWithDefaultValue(tradeDate, "")
+	if err != nil {
+		return
+	}
+
+	s.Date = date
+	s.SecurityId = secId
+	s.Before = before
+	s.After = after
+
+	return
+}
+
+func parseChangeover(data []byte, c *Changeover) (err error) {
+	actionDate, err := parseStringWithDefaultValueByKey(data, changeoverKeyActionDate, "")
+	if err != nil {
+		return
+	}
+
+	oldSecId, err := parseStringWithDefaultValueByKey(data, changeoverKeyOldSecId, "")
+	if err != nil {
+		return
+	}
+
+	newSecId, err := parseStringWithDefaultValueByKey(data, changeoverKeyNewSecId, "")
+	if err != nil {
+		return
+	}
+
+	date, err := parseDateTimeWithDefaultValue(actionDate, "")
+	if err != nil {
+		return
+	}
+
+	c.Date = date
+	c.OldSecurityId = oldSecId
+	c.NewSecurityId = newSecId
+
+	return
+}
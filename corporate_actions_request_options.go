@@ -0,0 +1,86 @@
+package moexiss
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// CorporateActionsRequestOptions contains options which can be used as arguments
+// for building requests to get splits and ticker changeovers.
+// MoEx ISS API endpoints:
+//
+// https://iss.moex.com/iss/statistics/engines/stock/splits
+// https://iss.moex.com/iss/history/engines/stock/markets/shares/securities/changeover
+type CorporateActionsRequestOptions struct {
+	lang  Language // `lang` query parameter in url.URL
+	start uint64   // `start` query parameter in url.URL
+}
+
+// CorporateActionsReqOptionsBuilder represents a builder of CorporateActionsRequestOptions struct
+type CorporateActionsReqOptionsBuilder struct {
+	options *CorporateActionsRequestOptions
+}
+
+// NewCorporateActionsReqOptionsBuilder is a constructor of CorporateActionsReqOptionsBuilder
+func NewCorporateActionsReqOptionsBuilder() *CorporateActionsReqOptionsBuilder {
+	return &CorporateActionsReqOptionsBuilder{options: &CorporateActionsRequestOptions{}}
+}
+
+// Build builds CorporateActionsRequestOptions from CorporateActionsReqOptionsBuilder
+func (b *CorporateActionsReqOptionsBuilder) Build() *CorporateActionsRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds CorporateActionsRequestOptions from CorporateActionsReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *CorporateActionsReqOptionsBuilder) BuildValidated() (*CorporateActionsRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of CorporateActionsRequestOptions,
+// options can be nil, it is safe
+func (options *CorporateActionsRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *CorporateActionsReqOptionsBuilder) Lang(lang Language) *CorporateActionsReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// Start sets 'start' parameter to a request
+// Row number (the number of the first row is 0) to begin the result set with.
+// 0 by default
+func (b *CorporateActionsReqOptionsBuilder) Start(start uint64) *CorporateActionsReqOptionsBuilder {
+	b.options.start = start
+	return b
+}
+
+// addCorporateActionsRequestOptions sets parameters into *url.URL
+// from CorporateActionsRequestOptions struct and returns it back
+func addCorporateActionsRequestOptions(url *url.URL, options *CorporateActionsRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+	if options.start != 0 {
+		q.Set("start", strconv.FormatUint(options.start, 10))
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
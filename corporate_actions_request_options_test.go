@@ -0,0 +1,72 @@
+package moexiss
+
+import (
+	"testing"
+)
+
+func TestCorporateActionsReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := CorporateActionsRequestOptions{}
+	bld := NewCorporateActionsReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` CorporateActionsRequestOptions \ngot `%v` CorporateActionsRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestCorporateActionsReqOptionsBuilder_Lang(t *testing.T) {
+	expectStruct := CorporateActionsRequestOptions{lang: LangEn}
+	bld := NewCorporateActionsReqOptionsBuilder()
+	bld.Lang(LangEn)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestCorporateActionsReqOptionsBuilder_Start(t *testing.T) {
+	expectStruct := CorporateActionsRequestOptions{start: 100}
+	bld := NewCorporateActionsReqOptionsBuilder()
+	bld.Start(100)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewCorporateActionsRequestOptions(t *testing.T) {
+	expectStruct := CorporateActionsRequestOptions{
+		lang:  LangRu,
+		start: 10,
+	}
+	bld := NewCorporateActionsReqOptionsBuilder()
+	bld.Lang(LangRu).Start(10)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+
+}
+
+func TestAddCorporateActionsRequestOptionsNilOptions(t *testing.T) {
+	var income *CorporateActionsRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addCorporateActionsRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddCorporateActionsRequestOptions(t *testing.T) {
+	var incomeOptions = NewCorporateActionsReqOptionsBuilder().
+		Lang(LangEn).
+		Start(100).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addCorporateActionsRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&lang=en&start=100`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,275 @@
+package moexiss
+
+import (
+	"context"
+	"fmt"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getCorporateActionsSrv(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fileName := "splits.json"
+		if strings.HasSuffix(r.URL.Path, "/changeover.json") {
+			fileName = "changeover.json"
+		}
+		byteValue, err := getTestingData(fileName)
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+}
+
+func msk(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, MoscowLocation)
+}
+
+func TestSplitRatio(t *testing.T) {
+	type Case struct {
+		split    Split
+		expected float64
+	}
+	cases := []Case{
+		{Split{Before: 1, After: 100}, 100},
+		{Split{Before: 5000, After: 1}, 0.0002},
+		{Split{Before: 0, After: 1}, 0},
+		{Split{Before: 1, After: -1}, 0},
+	}
+	for i, c := range cases {
+		if got := c.split.Ratio(); got != c.expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", c.expected, got, i)
+		}
+	}
+}
+
+func TestParseSplit(t *testing.T) {
+	expectedStruct := Split{Date: msk(2021, time.July, 1), SecurityId: "GMKN", Before: 1, After: 100}
+	var incomeJSON = `
+{"tradedate": "2021-07-01", "secid": "GMKN", "before": 1, "after": 100}
+`
+	s := Split{}
+	if err := parseSplit([]byte(incomeJSON), &s); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := s, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseChangeover(t *testing.T) {
+	expectedStruct := Changeover{Date: msk(2023, time.August, 21), OldSecurityId: "YNDX", NewSecurityId: "YDEX"}
+	var incomeJSON = `
+{"action_date": "2023-08-21", "old_secid": "YNDX", "old_isin": "NL0009805522", "new_secid": "YDEX", "new_isin": "RU000A107T19"}
+`
+	c := Changeover{}
+	if err := parseChangeover([]byte(incomeJSON), &c); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := c, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseCorporateActionsErrCases(t *testing.T) {
+	if got, expected := parseSplit([]byte(`{"tradedate": "2021-07-01", "secid": "GMKN", "before": 1}`), &Split{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseChangeover([]byte(`{"action_date": "2023-08-21", "old_secid": "YNDX"}`), &Changeover{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCorporateActionsService_Splits(t *testing.T) {
+	srv := getCorporateActionsSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	splits, err := c.CorporateActions.Splits(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(splits), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := splits[3].Before, int64(5000); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCorporateActionsService_SecuritySplits(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"splits": [{"tradedate": "2021-07-01", "secid": "GMKN", "before": 1, "after": 100}]}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	splits, err := c.CorporateActions.SecuritySplits(context.Background(), "GMKN", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := gotPath, "/statistics/engines/stock/splits/GMKN.json"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := len(splits), 1; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if _, err = c.CorporateActions.SecuritySplits(context.Background(), "", nil); err != ErrBadSecurityParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadSecurityParameter, err)
+	}
+}
+
+func TestCorporateActionsService_ChangeoversPages(t *testing.T) {
+	starts := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := r.URL.Query().Get("start")
+		starts = append(starts, start)
+		index := 0
+		if start != "" {
+			_, _ = fmt.Sscan(start, &index)
+		}
+		_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"changeover": [{"action_date": "2023-08-21", "old_secid": "A%d", "new_secid": "B%d"}], "changeover.cursor": [{"INDEX": %d, "TOTAL": 3, "PAGESIZE": 1}]}]`,
+			index, index, index)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	changeovers, err := c.CorporateActions.Changeovers(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(changeovers), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := fmt.Sprint(starts), "[ 1 2]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCorporateActionsService_Changeovers(t *testing.T) {
+	srv := getCorporateActionsSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	changeovers, err := c.CorporateActions.Changeovers(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(changeovers), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := fmt.Sprint(SecurityIdsOf("YDEXX", changeovers)), "[YDEXX YDEX YNDX]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCorporateActionsService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.CorporateActions.Splits(context.Background(), nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+	if _, err := c.CorporateActions.Changeovers(context.Background(), nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestCorporateActionsNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	if _, err := c.CorporateActions.Splits(ctx, nil); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
+
+func TestSecurityIdsOf(t *testing.T) {
+	changeovers := []Changeover{
+		{Date: msk(2021, time.May, 17), OldSecurityId: "MOEX", NewSecurityId: "MOEX"},
+		{Date: msk(2020, time.January, 10), OldSecurityId: "B", NewSecurityId: "A"},
+		{Date: msk(2022, time.January, 10), OldSecurityId: "C", NewSecurityId: "B"},
+		{Date: msk(2015, time.January, 10), OldSecurityId: "C", NewSecurityId: "B"},
+		{Date: msk(2010, time.January, 10), OldSecurityId: "A", NewSecurityId: "C"},
+	}
+	type Case struct {
+		secId    string
+		expected string
+	}
+	cases := []Case{
+		{"MOEX", "[MOEX]"},
+		{"A", "[A B C]"}, // the later changeover C -> B isn't followed, A -> C closes a cycle
+		{"C", "[C A]"},
+		{"D", "[D]"},
+	}
+	for i, c := range cases {
+		if got := fmt.Sprint(SecurityIdsOf(c.secId, changeovers)); got != c.expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", c.expected, got, i)
+		}
+	}
+}
+
+func TestAdjustPrices(t *testing.T) {
+	splits := []Split{
+		{Date: msk(2021, time.July, 1), SecurityId: "GMKN", Before: 1, After: 100},
+		{Date: msk(2021, time.July, 1), SecurityId: "SBER", Before: 1, After: 10},
+		{Date: msk(2021, time.August, 2), SecurityId: "NEWT", Before: 2, After: 1},
+		{Date: msk(2021, time.August, 3), SecurityId: "NEWT", Before: 0, After: 1},
+	}
+	changeovers := []Changeover{
+		{Date: msk(2021, time.July, 15), OldSecurityId: "GMKN", NewSecurityId: "NEWT"},
+	}
+	prices := []DailyPrice{
+		{SecurityId: "GMKN", Date: msk(2021, time.June, 30), Open: 20000, High: 20400, Low: 19800, Close: 20000, Volume: 10},
+		{SecurityId: "SBER", Date: msk(2021, time.June, 30), Open: 300, High: 300, Low: 300, Close: 300, Volume: 1000},
+		{SecurityId: "GMKN", Date: msk(2021, time.July, 1), Open: 200, High: 204, Low: 198, Close: 200, Volume: 1000},
+		{SecurityId: "GMKN", Date: msk(2021, time.July, 20), Open: 1, High: 1, Low: 1, Close: 1, Volume: 1},
+		{SecurityId: "NEWT", Date: msk(2021, time.July, 20), Open: 210, High: 212, Low: 208, Close: 210, Volume: 500},
+		{SecurityId: "NEWT", Date: msk(2021, time.August, 2), Open: 420, High: 424, Low: 416, Close: 420, Volume: 250},
+	}
+	expected := []DailyPrice{
+		{SecurityId: "NEWT", Date: msk(2021, time.June, 30), Open: 400, High: 408, Low: 396, Close: 400, Volume: 500},
+		{SecurityId: "NEWT", Date: msk(2021, time.July, 1), Open: 400, High: 408, Low: 396, Close: 400, Volume: 500},
+		{SecurityId: "NEWT", Date: msk(2021, time.July, 20), Open: 420, High: 424, Low: 416, Close: 420, Volume: 250},
+		{SecurityId: "NEWT", Date: msk(2021, time.August, 2), Open: 420, High: 424, Low: 416, Close: 420, Volume: 250},
+	}
+	got := AdjustPrices("NEWT", prices, splits, changeovers)
+	if len(got) != len(expected) {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", len(expected), len(got))
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d record", expected[i], got[i], i)
+		}
+	}
+}
+
+func TestCorporateActionsService_SplitsStartIgnored(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"splits": [{"tradedate": "2021-07-01", "secid": "GMKN", "before": 1, "after": 100}], "splits.cursor": [{"INDEX": 0, "TOTAL": 2, "PAGESIZE": 1}]}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.CorporateActions.Splits(context.Background(), nil); err != ErrCursorNotAdvanced {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrCursorNotAdvanced, err)
+	}
+	if got, expected := requests, 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v requests\ngot:\n %v requests\ninstead", expected, got)
+	}
+}
@@ -0,0 +1,122 @@
+package moexiss
+
+import "github.com/buger/jsonparser"
+
+// Cursor represents a '<block>.cursor' block of MoEx ISS API
+// which describes a page of a paginated block
+type Cursor struct {
+	Index    int64 // "INDEX" Row number of the first row of the page
+	Total    int64 // "TOTAL" Total number of rows
+	PageSize int64 // "PAGESIZE" Number of rows per page
+}
+
+const (
+	cursorSuffix      = ".cursor"
+	cursorKeyIndex    = "INDEX"
+	cursorKeyTotal    = "TOTAL"
+	cursorKeyPageSize = "PAGESIZE"
+
+	// cursorMaxPages limits the number of pages requested by getAllPages
+	cursorMaxPages = 1000
+)
+
+// Next returns the 'start' parameter of the next page,
+// false means there is no next page
+func (c *Cursor) Next() (uint64, bool) {
+	if c == nil || c.PageSize <= 0 {
+		return 0, false
+	}
+	next := c.Index + c.PageSize
+	if next >= c.Total {
+		return 0, false
+	}
+	return uint64(next), true
+}
+
+// parseCursor parses the cursor block of the 'block' block of a section,
+// it returns nil if there is no cursor block
+func parseCursor(section []byte, block string) (*Cursor, error) {
+	data, dataType, _, err := jsonparser.Get(section, block+cursorSuffix, "[0]")
+	if err == jsonparser.KeyPathNotFoundError {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if dataType != jsonparser.Object {
+		return nil, ErrUnexpectedDataType
+	}
+
+	index, err := parseIntWithDefaultValue(data, cursorKeyIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := parseIntWithDefaultValue(data, cursorKeyTotal)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize, err := parseIntWithDefaultValue(data, cursorKeyPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cursor{Index: index, Total: total, PageSize: pageSize}, nil
+}
+
+// parseBlockWithCursor calls parseItem for every object of the 'block' block
+// and parses the cursor of the block, the cursor isn't parsed if 'cursor' is nil
+func parseBlockWithCursor(byteData []byte, block string, cursor **Cursor, parseItem func(data []byte) error) error {
+	var errInCb error
+	_, err := jsonparser.ArrayEach(byteData, func(sectionBytes []byte, _ jsonparser.ValueType, offset int, errCb error) {
+		var data []byte
+		var dataType jsonparser.ValueType
+		data, dataType, _, errInCb = jsonparser.Get(sectionBytes, block)
+		if errInCb == nil && data != nil && dataType == jsonparser.Array {
+			var errInItemCb error
+			_, errInCb = jsonparser.ArrayEach(data, func(itemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+				if errInItemCb != nil {
+					return
+				}
+				if dataType != jsonparser.Object {
+					errInItemCb = ErrUnexpectedDataType
+					return
+				}
+				errInItemCb = parseItem(itemData)
+			})
+			if errInCb == nil {
+				errInCb = errInItemCb
+			}
+			if errInCb == nil && cursor != nil {
+				*cursor, errInCb = parseCursor(sectionBytes, block)
+			}
+		}
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return err
+}
+
+// getAllPages calls getPage for every page beginning with 'start' following the cursor
+// returned by getPage until there is no next page.
+// It returns ErrTooManyPages if there are more than maxPages pages
+// and ErrCursorNotAdvanced if the cursor doesn't advance, e.g. a server ignores 'start'.
+func getAllPages(start uint64, maxPages int, getPage func(start uint64) (*Cursor, error)) error {
+	for page := 0; page < maxPages; page++ {
+		cursor, err := getPage(start)
+		if err != nil {
+			return err
+		}
+		if cursor != nil && cursor.Index < int64(start) {
+			return ErrCursorNotAdvanced
+		}
+		next, ok := cursor.Next()
+		if !ok {
+			return nil
+		}
+		start = next
+	}
+	return ErrTooManyPages
+}
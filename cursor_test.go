@@ -0,0 +1,155 @@
+package moexiss
+
+import (
+	"fmt"
+	"github.com/buger/jsonparser"
+	"testing"
+)
+
+func TestCursorNext(t *testing.T) {
+	type Case struct {
+		cursor   *Cursor
+		next     uint64
+		expected bool
+	}
+	cases := []Case{
+		{&Cursor{Index: 0, Total: 250, PageSize: 100}, 100, true},
+		{&Cursor{Index: 100, Total: 250, PageSize: 100}, 200, true},
+		{&Cursor{Index: 200, Total: 250, PageSize: 100}, 0, false},
+		{&Cursor{Index: 0, Total: 100, PageSize: 100}, 0, false},
+		{&Cursor{Index: 0, Total: 100, PageSize: 0}, 0, false},
+		{nil, 0, false},
+	}
+	for i, c := range cases {
+		next, ok := c.cursor.Next()
+		if next != c.next || ok != c.expected {
+			t.Fatalf("Error: expecting: \n %v, %v \ngot:\n %v, %v \ninstead in %d case", c.next, c.expected, next, ok, i)
+		}
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	var incomeJSON = `
+{"securities": [], "securities.cursor": [{"INDEX": 100, "TOTAL": 250, "PAGESIZE": 100}]}
+`
+	cursor, err := parseCursor([]byte(incomeJSON), "securities")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := *cursor, (Cursor{Index: 100, Total: 250, PageSize: 100}); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseCursorAbsent(t *testing.T) {
+	cursor, err := parseCursor([]byte(`{"securities": []}`), "securities")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if cursor != nil {
+		t.Fatalf("Error: expecting <nil> cursor \ngot %v \ninstead", cursor)
+	}
+}
+
+func TestParseCursorErrCases(t *testing.T) {
+	if _, err := parseCursor([]byte(`{"securities.cursor": [[]]}`), "securities"); err != ErrUnexpectedDataType {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrUnexpectedDataType, err)
+	}
+	if _, err := parseCursor([]byte(`{"securities.cursor": [{"INDEX": 0, "TOTAL": 10}]}`), "securities"); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestParseBlockWithCursor(t *testing.T) {
+	var incomeJSON = `
+[{"charsetinfo": {"name": "utf-8"}}, {"splits": [{"secid": "A"}, {"secid": "B"}], "splits.cursor": [{"INDEX": 0, "TOTAL": 5, "PAGESIZE": 2}]}]
+`
+	var cursor *Cursor
+	items := 0
+	err := parseBlockWithCursor([]byte(incomeJSON), "splits", &cursor, func(data []byte) error {
+		items++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := items, 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := *cursor, (Cursor{Index: 0, Total: 5, PageSize: 2}); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseBlockWithCursorUnexpectedDataTypeError(t *testing.T) {
+	var cursor *Cursor
+	err := parseBlockWithCursor([]byte(`[{"splits": [[]]}]`), "splits", &cursor, func(data []byte) error { return nil })
+	if got, expected := err, ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseBlockWithoutCursor(t *testing.T) {
+	var incomeJSON = `[{"charsetinfo": {"name": "utf-8"}}, {"boards": [{"id": 57}, {"id": 58}], "boards.cursor": [{"INDEX": "bad"}]}]`
+	items := 0
+	err := parseBlockWithCursor([]byte(incomeJSON), enginesKeyBoards, nil, func(data []byte) error {
+		items++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := items, 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	err = parseBlockWithCursor([]byte(`[{"boards": [[]]}]`), enginesKeyBoards, nil, func(data []byte) error { return nil })
+	if got, expected := err, ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	starts := make([]uint64, 0)
+	err := getAllPages(100, 10, func(start uint64) (*Cursor, error) {
+		starts = append(starts, start)
+		return &Cursor{Index: int64(start), Total: 450, PageSize: 100}, nil
+	})
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := fmt.Sprint(starts), "[100 200 300 400]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	pages := 0
+	err = getAllPages(0, 10, func(start uint64) (*Cursor, error) {
+		pages++
+		return nil, nil
+	})
+	if err != nil || pages != 1 {
+		t.Fatalf("Error: expecting a page without a cursor and <nil> error: \ngot %d pages and %v \ninstead", pages, err)
+	}
+}
+
+func TestGetAllPagesErrCases(t *testing.T) {
+	// a server which ignores 'start' returns the first page again
+	err := getAllPages(0, 10, func(start uint64) (*Cursor, error) {
+		return &Cursor{Index: 0, Total: 250, PageSize: 100}, nil
+	})
+	if err != ErrCursorNotAdvanced {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrCursorNotAdvanced, err)
+	}
+	pages := 0
+	err = getAllPages(0, 3, func(start uint64) (*Cursor, error) {
+		pages++
+		return &Cursor{Index: int64(start), Total: 1000, PageSize: 1}, nil
+	})
+	if err != ErrTooManyPages || pages != 3 {
+		t.Fatalf("Error: expecting %v error after 3 pages \ngot %v after %d pages \ninstead", ErrTooManyPages, err, pages)
+	}
+	err = getAllPages(0, 3, func(start uint64) (*Cursor, error) {
+		return nil, ErrUnexpectedDataType
+	})
+	if err != ErrUnexpectedDataType {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrUnexpectedDataType, err)
+	}
+}
@@ -0,0 +1,130 @@
+package moexiss
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DateRangeRequestOptions contains options which can be used as arguments
+// for building requests of data for a date or for a range of dates,
+// e.g. the zero-coupon yield curve, fixings, indicative rates and the market capitalization.
+// MoEx ISS API endpoints:
+//
+// https://iss.moex.com/iss/engines/stock/zcyc
+// https://iss.moex.com/iss/history/engines/stock/zcyc
+// https://iss.moex.com/iss/statistics/engines/currency/markets/fixing
+// https://iss.moex.com/iss/statistics/engines/futures/markets/indicativerates/securities
+// https://iss.moex.com/iss/statistics/engines/stock/capitalization
+// https://iss.moex.com/iss/statistics/engines/stock/quotedsecurities
+type DateRangeRequestOptions struct {
+	lang  Language  // `lang` query parameter in url.URL
+	date  time.Time // `date` query parameter in url.URL
+	from  time.Time // `from` query parameter in url.URL
+	till  time.Time // `till` query parameter in url.URL
+	start uint64    // `start` query parameter in url.URL
+}
+
+// DateRangeReqOptionsBuilder represents a builder of DateRangeRequestOptions struct
+type DateRangeReqOptionsBuilder struct {
+	options *DateRangeRequestOptions
+}
+
+// NewDateRangeReqOptionsBuilder is a constructor of DateRangeReqOptionsBuilder
+func NewDateRangeReqOptionsBuilder() *DateRangeReqOptionsBuilder {
+	return &DateRangeReqOptionsBuilder{options: &DateRangeRequestOptions{}}
+}
+
+// Build builds DateRangeRequestOptions from DateRangeReqOptionsBuilder
+func (b *DateRangeReqOptionsBuilder) Build() *DateRangeRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds DateRangeRequestOptions from DateRangeReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *DateRangeReqOptionsBuilder) BuildValidated() (*DateRangeRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of DateRangeRequestOptions,
+// options can be nil, it is safe
+func (options *DateRangeRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return firstError(
+		validateLang("lang", options.lang),
+		validateNotFutureDate("date", options.date),
+		validateNotFutureDate("from", options.from),
+		validateNotFutureDate("till", options.till),
+		validateDateRange(options.from, options.till),
+	)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *DateRangeReqOptionsBuilder) Lang(lang Language) *DateRangeReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// Date sets 'date' parameter to a request
+// 'date' is the date of the data, e.g. of the curve.
+// By default(if none), for the last available date.
+func (b *DateRangeReqOptionsBuilder) Date(date time.Time) *DateRangeReqOptionsBuilder {
+	b.options.date = date
+	return b
+}
+
+// From sets 'from' parameter to a request of the history
+func (b *DateRangeReqOptionsBuilder) From(from time.Time) *DateRangeReqOptionsBuilder {
+	b.options.from = from
+	return b
+}
+
+// Till sets 'till' parameter to a request of the history
+func (b *DateRangeReqOptionsBuilder) Till(till time.Time) *DateRangeReqOptionsBuilder {
+	b.options.till = till
+	return b
+}
+
+// Start sets 'start' parameter to a request of the history
+// Row number (the number of the first row is 0) to begin the result set with.
+// 0 by default
+func (b *DateRangeReqOptionsBuilder) Start(start uint64) *DateRangeReqOptionsBuilder {
+	b.options.start = start
+	return b
+}
+
+// addDateRangeRequestOptions sets parameters into *url.URL
+// from DateRangeRequestOptions struct and returns it back
+func addDateRangeRequestOptions(url *url.URL, options *DateRangeRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+	if !options.date.IsZero() {
+		q.Set("date", options.date.Format("2006-01-02"))
+	}
+	if !options.from.IsZero() {
+		q.Set("from", options.from.Format("2006-01-02"))
+	}
+	if !options.till.IsZero() {
+		q.Set("till", options.till.Format("2006-01-02"))
+	}
+	if options.start != 0 {
+		q.Set("start", strconv.FormatUint(options.start, 10))
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
@@ -0,0 +1,92 @@
+package moexiss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRangeReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := DateRangeRequestOptions{}
+	bld := NewDateRangeReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` DateRangeRequestOptions \ngot `%v` DateRangeRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestNewDateRangeRequestOptions(t *testing.T) {
+	date := time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+	till := time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+	expectStruct := DateRangeRequestOptions{
+		lang:  LangEn,
+		date:  date,
+		from:  from,
+		till:  till,
+		start: 10,
+	}
+	bld := NewDateRangeReqOptionsBuilder().
+		Lang(LangEn).
+		Date(date).
+		From(from).
+		Till(till).
+		Start(10)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestAddDateRangeRequestOptionsNilOptions(t *testing.T) {
+	var income *DateRangeRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addDateRangeRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddDateRangeRequestOptions(t *testing.T) {
+	var incomeOptions = NewDateRangeReqOptionsBuilder().
+		Lang(LangEn).
+		Date(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)).
+		From(time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)).
+		Till(time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)).
+		Start(10).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addDateRangeRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?date=2022-03-04&from=2022-01-10&iss.json=extended&iss.meta=off&lang=en&start=10&till=2022-02-10`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestDateRangeReqOptionsBuilder_BuildValidated(t *testing.T) {
+	checkBuilderCases(t, []builderCase{
+		{func() (bool, error) {
+			opt, err := NewDateRangeReqOptionsBuilder().Date(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewDateRangeReqOptionsBuilder().Date(time.Now().AddDate(0, 0, 2)).BuildValidated()
+			return opt != nil, err
+		}, "date"},
+		{func() (bool, error) {
+			opt, err := NewDateRangeReqOptionsBuilder().From(time.Now().AddDate(0, 0, 2)).BuildValidated()
+			return opt != nil, err
+		}, "from"},
+		{func() (bool, error) {
+			opt, err := NewDateRangeReqOptionsBuilder().Till(time.Now().AddDate(0, 0, 2)).BuildValidated()
+			return opt != nil, err
+		}, "till"},
+		{func() (bool, error) {
+			opt, err := NewDateRangeReqOptionsBuilder().From(time.Date(2023, 12, 2, 0, 0, 0, 0, time.UTC)).Till(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)).BuildValidated()
+			return opt != nil, err
+		}, "till"},
+	})
+}
@@ -0,0 +1,529 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/buger/jsonparser"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OptionType represents a type of an option
+type OptionType string
+
+// A section of OptionType values
+const (
+	OptionTypeUndefined OptionType = ""
+	OptionTypeCall      OptionType = "C"
+	OptionTypePut       OptionType = "P"
+)
+
+// String representations of OptionType values
+func (o OptionType) String() string {
+	return string(o)
+}
+
+// FuturesSeries struct represents a futures contract of the derivatives market
+type FuturesSeries struct {
+	SecurityId       string    // "SECID"
+	ShortName        string    // "SHORTNAME"
+	AssetCode        string    // "ASSETCODE" Underlying asset code
+	Expiration       time.Time // "LASTTRADEDATE" in MoscowLocation
+	LastDelDate      time.Time // "LASTDELDATE" in MoscowLocation
+	MinStep          float64   // "MINSTEP"
+	PrevSettlePrice  float64   // "PREVSETTLEPRICE"
+	PrevOpenPosition int64     // "PREVOPENPOSITION"
+}
+
+// OptionSeries struct represents an option contract of the derivatives market
+type OptionSeries struct {
+	SecurityId      string     // "SECID"
+	ShortName       string     // "SHORTNAME"
+	AssetCode       string     // "ASSETCODE" Underlying asset code
+	UnderlyingAsset string     // "UNDERLYINGASSET" Underlying futures contract
+	Type            OptionType // "OPTIONTYPE"
+	Strike          float64    // "STRIKE"
+	Expiration      time.Time  // "LASTTRADEDATE" in MoscowLocation
+	PrevSettlePrice float64    // "PREVSETTLEPRICE"
+	TheorPrice      float64    // "THEORPRICE" of "marketdata" block
+	Volatility      float64    // "VOLATILITY" of "marketdata" block, %
+	OpenPosition    int64      // "OPENPOSITION" of "marketdata" block
+}
+
+// FuturesSeriesResponse struct represents a response with futures series of the underlying asset
+type FuturesSeriesResponse struct {
+	AssetCode string
+	Series    []FuturesSeries // ordered by Expiration
+}
+
+// OptionSeriesResponse struct represents a response with option series of the underlying asset
+type OptionSeriesResponse struct {
+	AssetCode string
+	Series    []OptionSeries // ordered by Expiration, Strike and Type
+}
+
+// OptionBoardRow struct represents a call and a put with the same strike,
+// Call or Put is nil if there is no such an option
+type OptionBoardRow struct {
+	Strike float64
+	Call   *OptionSeries
+	Put    *OptionSeries
+}
+
+// OptionBoard struct represents options of the underlying asset with the same expiration
+type OptionBoard struct {
+	AssetCode  string
+	Expiration time.Time
+	Rows       []OptionBoardRow // ordered by Strike
+}
+
+const (
+	derivativesFortsPartOfPath   = "forts"
+	derivativesOptionsPartOfPath = "options"
+	derivativesSecuritiesUrl     = "securities.json"
+	derivativesEndpoint          = "engines/{engine}/markets/{market}/securities"
+
+	derivativesKeySecId            = "SECID"
+	derivativesKeyShortName        = "SHORTNAME"
+	derivativesKeyAssetCode        = "ASSETCODE"
+	derivativesKeyUnderlyingAsset  = "UNDERLYINGASSET"
+	derivativesKeyOptionType       = "OPTIONTYPE"
+	derivativesKeyStrike           = "STRIKE"
+	derivativesKeyLastTradeDate    = "LASTTRADEDATE"
+	derivativesKeyLastDelDate      = "LASTDELDATE"
+	derivativesKeyMinStep          = "MINSTEP"
+	derivativesKeyPrevSettlePrice  = "PREVSETTLEPRICE"
+	derivativesKeyPrevOpenPosition = "PREVOPENPOSITION"
+	derivativesKeyTheorPrice       = "THEORPRICE"
+	derivativesKeyVolatility       = "VOLATILITY"
+	derivativesKeyOpenPosition     = "OPENPOSITION"
+	derivativesKeySecurities       = "securities"
+	derivativesKeyMarketData       = "marketdata"
+)
+
+// DerivativesService gets futures and options series of the derivatives market(FORTS)
+// from the MoEx ISS API.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/engines/futures/markets/forts/securities
+// https://iss.moex.com/iss/engines/futures/markets/options/securities
+type DerivativesService service
+
+// GetFuturesSeries provides futures series of the underlying asset(e.g. "Si", "RTS")
+func (d *DerivativesService) GetFuturesSeries(ctx context.Context, asset string, opt *DerivativesRequestOptions) (*FuturesSeriesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if asset == "" {
+		return nil, ErrBadSecurityParameter
+	}
+	b, err := d.get(ctx, d.getUrl(derivativesFortsPartOfPath, opt))
+	if err != nil {
+		return nil, err
+	}
+	series := make([]FuturesSeries, 0)
+	err = parseFuturesSeriesResponse(b, &series)
+	if err != nil {
+		return nil, err
+	}
+	fr := FuturesSeriesResponse{AssetCode: asset, Series: make([]FuturesSeries, 0)}
+	for _, s := range series {
+		if strings.EqualFold(s.AssetCode, asset) {
+			fr.Series = append(fr.Series, s)
+		}
+	}
+	sort.SliceStable(fr.Series, func(i, j int) bool {
+		return fr.Series[i].Expiration.Before(fr.Series[j].Expiration)
+	})
+	return &fr, nil
+}
+
+// GetOptionSeries provides option series of the underlying asset(e.g. "Si", "RTS")
+// with theoretical prices, volatilities and open positions
+func (d *DerivativesService) GetOptionSeries(ctx context.Context, asset string, opt *DerivativesRequestOptions) (*OptionSeriesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if asset == "" {
+		return nil, ErrBadSecurityParameter
+	}
+	b, err := d.get(ctx, d.getUrl(derivativesOptionsPartOfPath, opt))
+	if err != nil {
+		return nil, err
+	}
+	series := make([]OptionSeries, 0)
+	err = parseOptionSeriesResponse(b, &series)
+	if err != nil {
+		return nil, err
+	}
+	or := OptionSeriesResponse{AssetCode: asset, Series: make([]OptionSeries, 0)}
+	for _, s := range series {
+		if strings.EqualFold(s.AssetCode, asset) {
+			or.Series = append(or.Series, s)
+		}
+	}
+	sort.SliceStable(or.Series, func(i, j int) bool {
+		a, b := or.Series[i], or.Series[j]
+		if !a.Expiration.Equal(b.Expiration) {
+			return a.Expiration.Before(b.Expiration)
+		}
+		if a.Strike != b.Strike {
+			return a.Strike < b.Strike
+		}
+		return a.Type < b.Type
+	})
+	return &or, nil
+}
+
+// GetOptionBoard provides the option board of the underlying asset for the expiration date,
+// see OptionSeriesResponse.Board
+func (d *DerivativesService) GetOptionBoard(ctx context.Context, asset string, expiration time.Time, opt *DerivativesRequestOptions) (*OptionBoard, error) {
+	or, err := d.GetOptionSeries(ctx, asset, opt)
+	if err != nil {
+		return nil, err
+	}
+	return or.Board(expiration), nil
+}
+
+// Expirations returns the distinct expiration dates of the option series in ascending order
+func (r *OptionSeriesResponse) Expirations() []time.Time {
+	result := make([]time.Time, 0)
+	for _, s := range r.Series {
+		if !containsDate(result, s.Expiration) {
+			result = append(result, s.Expiration)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+// Board returns the option board for the expiration date,
+// only the calendar date of 'expiration' in its own location is taken into account
+func (r *OptionSeriesResponse) Board(expiration time.Time) *OptionBoard {
+	board := &OptionBoard{AssetCode: r.AssetCode, Rows: make([]OptionBoardRow, 0)}
+	for i := range r.Series {
+		s := &r.Series[i]
+		if !sameDate(s.Expiration, expiration) {
+			continue
+		}
+		board.Expiration = s.Expiration
+		k := sort.Search(len(board.Rows), func(j int) bool { return board.Rows[j].Strike >= s.Strike })
+		if k == len(board.Rows) || board.Rows[k].Strike != s.Strike {
+			board.Rows = append(board.Rows, OptionBoardRow{})
+			copy(board.Rows[k+1:], board.Rows[k:])
+			board.Rows[k] = OptionBoardRow{Strike: s.Strike}
+		}
+		switch s.Type {
+		case OptionTypeCall:
+			board.Rows[k].Call = s
+		case OptionTypePut:
+			board.Rows[k].Put = s
+		}
+	}
+	return board
+}
+
+// containsDate reports whether dates contain the date
+func containsDate(dates []time.Time, date time.Time) bool {
+	for _, d := range dates {
+		if d.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameDate reports whether a and b are the same calendar date,
+// each of them is read in its own location
+func sameDate(a, b time.Time) bool {
+	return calendarDate(a).Equal(calendarDate(b))
+}
+
+func (d *DerivativesService) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := d.client.newEndpointRequest(derivativesEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = d.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// getUrl provides an url for a request of securities of the market of the derivatives market
+// opt *DerivativesRequestOptions can be nil, it is safe
+func (d *DerivativesService) getUrl(market string, opt *DerivativesRequestOptions) string {
+	url, _ := d.client.BaseURL.Parse(enginePartOfPath)
+	url.Path = path.Join(url.Path, EngineFutures.String(), marketsPartOfPath, market, derivativesSecuritiesUrl)
+	gotURL := addDerivativesRequestOptions(url, opt)
+	return gotURL.String()
+}
+
+func parseFuturesSeriesResponse(byteData []byte, series *[]FuturesSeries) error {
+	var err error
+	if series == nil {
+		err = ErrNilPointer
+		return err
+	}
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(futuresBytes []byte, _ jsonparser.ValueType, offset int, errCb error) {
+		var data []byte
+		var dataType jsonparser.ValueType
+		data, dataType, _, errInCb = jsonparser.Get(futuresBytes, derivativesKeySecurities)
+		if errInCb == nil && data != nil && dataType == jsonparser.Array {
+			errInCb = parseFuturesSeriesItems(data, series)
+		}
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return err
+}
+
+func parseFuturesSeriesItems(byteData []byte, series *[]FuturesSeries) (err error) {
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(itemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.Object {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+
+		s := FuturesSeries{}
+		errInCb = parseFuturesSeries(itemData, &s)
+		if errInCb != nil {
+			return
+		}
+		*series = append(*series, s)
+
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return
+}
+
+func parseFuturesSeries(data []byte, s *FuturesSeries) (err error) {
+	secId, err := parseStringWithDefaultValueByKey(data, derivativesKeySecId, "")
+	if err != nil {
+		return
+	}
+
+	shortName, err := parseStringWithDefaultValueByKey(data, derivativesKeyShortName, "")
+	if err != nil {
+		return
+	}
+
+	assetCode, err := parseStringWithDefaultValueByKey(data, derivativesKeyAssetCode, "")
+	if err != nil {
+		return
+	}
+
+	lastTradeDate, err := parseStringWithDefaultValueByKey(data, derivativesKeyLastTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	lastDelDate, err := parseStringWithDefaultValueByKey(data, derivativesKeyLastDelDate, "")
+	if err != nil {
+		return
+	}
+
+	minStep, err := parseFloatWithDefaultValue(data, derivativesKeyMinStep)
+	if err != nil {
+		return
+	}
+
+	prevSettlePrice, err := parseFloatWithDefaultValue(data, derivativesKeyPrevSettlePrice)
+	if err != nil {
+		return
+	}
+
+	prevOpenPosition, err := parseIntWithDefaultValue(data, derivativesKeyPrevOpenPosition)
+	if err != nil {
+		return
+	}
+
+	expiration, err := parseDateTimeWithDefaultValue(lastTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	delDate, err := parseDateTimeWithDefaultValue(lastDelDate, "")
+	if err != nil {
+		return
+	}
+
+	s.SecurityId = secId
+	s.ShortName = shortName
+	s.AssetCode = assetCode
+	s.Expiration = expiration
+	s.LastDelDate = delDate
+	s.MinStep = minStep
+	s.PrevSettlePrice = prevSettlePrice
+	s.PrevOpenPosition = prevOpenPosition
+
+	return
+}
+
+func parseOptionSeriesResponse(byteData []byte, series *[]OptionSeries) error {
+	var err error
+	if series == nil {
+		err = ErrNilPointer
+		return err
+	}
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(optionsBytes []byte, _ jsonparser.ValueType, offset int, errCb error) {
+		var data []byte
+		var dataType jsonparser.ValueType
+		data, dataType, _, errInCb = jsonparser.Get(optionsBytes, derivativesKeySecurities)
+		if errInCb == nil && data != nil && dataType == jsonparser.Array {
+			errInCb = parseOptionSeriesItems(data, series)
+			if errInCb != nil {
+				return
+			}
+			data, dataType, _, _ = jsonparser.Get(optionsBytes, derivativesKeyMarketData)
+			if data != nil && dataType == jsonparser.Array {
+				errInCb = parseOptionMarketData(data, *series)
+			}
+		}
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return err
+}
+
+func parseOptionSeriesItems(byteData []byte, series *[]OptionSeries) (err error) {
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(itemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.Object {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+
+		s := OptionSeries{}
+		errInCb = parseOptionSeries(itemData, &s)
+		if errInCb != nil {
+			return
+		}
+		*series = append(*series, s)
+
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return
+}
+
+func parseOptionSeries(data []byte, s *OptionSeries) (err error) {
+	secId, err := parseStringWithDefaultValueByKey(data, derivativesKeySecId, "")
+	if err != nil {
+		return
+	}
+
+	shortName, err := parseStringWithDefaultValueByKey(data, derivativesKeyShortName, "")
+	if err != nil {
+		return
+	}
+
+	assetCode, err := parseStringWithDefaultValueByKey(data, derivativesKeyAssetCode, "")
+	if err != nil {
+		return
+	}
+
+	underlyingAsset, err := parseStringWithDefaultValueByKey(data, derivativesKeyUnderlyingAsset, "")
+	if err != nil {
+		return
+	}
+
+	optionType, err := parseStringWithDefaultValueByKey(data, derivativesKeyOptionType, "")
+	if err != nil {
+		return
+	}
+
+	strike, err := parseFloatWithDefaultValue(data, derivativesKeyStrike)
+	if err != nil {
+		return
+	}
+
+	lastTradeDate, err := parseStringWithDefaultValueByKey(data, derivativesKeyLastTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	prevSettlePrice, err := parseFloatWithDefaultValue(data, derivativesKeyPrevSettlePrice)
+	if err != nil {
+		return
+	}
+
+	expiration, err := parseDateTimeWithDefaultValue(lastTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	s.SecurityId = secId
+	s.ShortName = shortName
+	s.AssetCode = assetCode
+	s.UnderlyingAsset = underlyingAsset
+	s.Type = OptionType(optionType)
+	s.Strike = strike
+	s.Expiration = expiration
+	s.PrevSettlePrice = prevSettlePrice
+
+	return
+}
+
+// parseOptionMarketData fills market data of the series by "SECID"
+func parseOptionMarketData(byteData []byte, series []OptionSeries) (err error) {
+	indexes := make(map[string]int, len(series))
+	for i, s := range series {
+		indexes[s.SecurityId] = i
+	}
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(itemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.Object {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+
+		var secId string
+		secId, errInCb = parseStringWithDefaultValueByKey(itemData, derivativesKeySecId, "")
+		if errInCb != nil {
+			return
+		}
+		i, ok := indexes[secId]
+		if !ok {
+			return
+		}
+		s := &series[i]
+		if s.TheorPrice, errInCb = parseFloatWithDefaultValue(itemData, derivativesKeyTheorPrice); errInCb != nil {
+			return
+		}
+		if s.Volatility, errInCb = parseFloatWithDefaultValue(itemData, derivativesKeyVolatility); errInCb != nil {
+			return
+		}
+		s.OpenPosition, errInCb = parseIntWithDefaultValue(itemData, derivativesKeyOpenPosition)
+
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return
+}
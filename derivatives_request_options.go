@@ -0,0 +1,71 @@
+package moexiss
+
+import "net/url"
+
+// DerivativesRequestOptions contains options which can be used as arguments
+// for building requests to get futures and options series of the derivatives market.
+// MoEx ISS API endpoints:
+//
+// https://iss.moex.com/iss/engines/futures/markets/forts/securities
+// https://iss.moex.com/iss/engines/futures/markets/options/securities
+type DerivativesRequestOptions struct {
+	lang Language // `lang` query parameter in url.URL
+}
+
+// DerivativesReqOptionsBuilder represents a builder of DerivativesRequestOptions struct
+type DerivativesReqOptionsBuilder struct {
+	options *DerivativesRequestOptions
+}
+
+// NewDerivativesReqOptionsBuilder is a constructor of DerivativesReqOptionsBuilder
+func NewDerivativesReqOptionsBuilder() *DerivativesReqOptionsBuilder {
+	return &DerivativesReqOptionsBuilder{options: &DerivativesRequestOptions{}}
+}
+
+// Build builds DerivativesRequestOptions from DerivativesReqOptionsBuilder
+func (b *DerivativesReqOptionsBuilder) Build() *DerivativesRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds DerivativesRequestOptions from DerivativesReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *DerivativesReqOptionsBuilder) BuildValidated() (*DerivativesRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of DerivativesRequestOptions,
+// options can be nil, it is safe
+func (options *DerivativesRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *DerivativesReqOptionsBuilder) Lang(lang Language) *DerivativesReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// addDerivativesRequestOptions sets parameters into *url.URL
+// from DerivativesRequestOptions struct and returns it back
+func addDerivativesRequestOptions(url *url.URL, options *DerivativesRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
@@ -0,0 +1,61 @@
+package moexiss
+
+import (
+	"testing"
+)
+
+func TestDerivativesReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := DerivativesRequestOptions{}
+	bld := NewDerivativesReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` DerivativesRequestOptions \ngot `%v` DerivativesRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestDerivativesReqOptionsBuilder_Lang(t *testing.T) {
+	expectStruct := DerivativesRequestOptions{lang: LangEn}
+	bld := NewDerivativesReqOptionsBuilder()
+	bld.Lang(LangEn)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewDerivativesRequestOptions(t *testing.T) {
+	expectStruct := DerivativesRequestOptions{
+		lang: LangRu,
+	}
+	bld := NewDerivativesReqOptionsBuilder()
+	bld.Lang(LangRu)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+
+}
+
+func TestAddDerivativesRequestOptionsNilOptions(t *testing.T) {
+	var income *DerivativesRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addDerivativesRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddDerivativesRequestOptions(t *testing.T) {
+	var incomeOptions = NewDerivativesReqOptionsBuilder().
+		Lang(LangEn).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addDerivativesRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&lang=en`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
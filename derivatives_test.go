@@ -0,0 +1,261 @@
+package moexiss
+
+import (
+	"context"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getDerivativesSrv(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fileName := "forts_securities.json"
+		if strings.Contains(r.URL.Path, "/options/") {
+			fileName = "options_securities.json"
+		}
+		byteValue, err := getTestingData(fileName)
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+}
+
+func TestDerivativesGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	if got, expected := c.Derivatives.getUrl(derivativesFortsPartOfPath, nil), `https://iss.moex.com/iss/engines/futures/markets/forts/securities.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+	if got, expected := c.Derivatives.getUrl(derivativesOptionsPartOfPath, nil), `https://iss.moex.com/iss/engines/futures/markets/options/securities.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestOptionType_String(t *testing.T) {
+	if got, expected := OptionTypeCall.String(), "C"; got != expected {
+		t.Fatalf("Error: expecting `%s` \ngot `%s` \ninstead", expected, got)
+	}
+	if got, expected := OptionTypePut.String(), "P"; got != expected {
+		t.Fatalf("Error: expecting `%s` \ngot `%s` \ninstead", expected, got)
+	}
+}
+
+func TestParseFuturesSeries(t *testing.T) {
+	expectedStruct := FuturesSeries{
+		SecurityId:       "SiH2",
+		ShortName:        "Si-3.22",
+		AssetCode:        "Si",
+		Expiration:       time.Date(2022, 3, 17, 0, 0, 0, 0, MoscowLocation),
+		LastDelDate:      time.Date(2022, 3, 17, 0, 0, 0, 0, MoscowLocation),
+		MinStep:          1,
+		PrevSettlePrice:  105712,
+		PrevOpenPosition: 2315870,
+	}
+	var incomeJSON = `
+{"SECID": "SiH2", "SHORTNAME": "Si-3.22", "ASSETCODE": "Si", "LASTTRADEDATE": "2022-03-17", "LASTDELDATE": "2022-03-17", "MINSTEP": 1, "PREVSETTLEPRICE": 105712, "PREVOPENPOSITION": 2315870}
+`
+	s := FuturesSeries{}
+	if err := parseFuturesSeries([]byte(incomeJSON), &s); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := s, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseFuturesSeriesErrCases(t *testing.T) {
+	cases := []string{
+		// no ASSETCODE
+		`{"SECID": "SiH2", "SHORTNAME": "Si-3.22", "LASTTRADEDATE": "2022-03-17", "LASTDELDATE": "2022-03-17", "MINSTEP": 1, "PREVSETTLEPRICE": 105712, "PREVOPENPOSITION": 2315870}`,
+		// no LASTTRADEDATE
+		`{"SECID": "SiH2", "SHORTNAME": "Si-3.22", "ASSETCODE": "Si", "LASTDELDATE": "2022-03-17", "MINSTEP": 1, "PREVSETTLEPRICE": 105712, "PREVOPENPOSITION": 2315870}`,
+		// no PREVOPENPOSITION
+		`{"SECID": "SiH2", "SHORTNAME": "Si-3.22", "ASSETCODE": "Si", "LASTTRADEDATE": "2022-03-17", "LASTDELDATE": "2022-03-17", "MINSTEP": 1, "PREVSETTLEPRICE": 105712}`,
+	}
+	for i, c := range cases {
+		s := FuturesSeries{}
+		if got, expected := parseFuturesSeries([]byte(c), &s), jsonparser.KeyPathNotFoundError; got != expected {
+			t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
+
+func TestParseOptionSeriesErrCases(t *testing.T) {
+	cases := []string{
+		// no OPTIONTYPE
+		`{"SECID": "Si105000BC2", "SHORTNAME": "Si", "ASSETCODE": "Si", "UNDERLYINGASSET": "SiH2", "STRIKE": 105000, "LASTTRADEDATE": "2022-03-17", "PREVSETTLEPRICE": 4450}`,
+		// no STRIKE
+		`{"SECID": "Si105000BC2", "SHORTNAME": "Si", "ASSETCODE": "Si", "UNDERLYINGASSET": "SiH2", "OPTIONTYPE": "C", "LASTTRADEDATE": "2022-03-17", "PREVSETTLEPRICE": 4450}`,
+	}
+	for i, c := range cases {
+		s := OptionSeries{}
+		if got, expected := parseOptionSeries([]byte(c), &s), jsonparser.KeyPathNotFoundError; got != expected {
+			t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
+
+func TestParseOptionSeriesBadDate(t *testing.T) {
+	var incomeJSON = `{"SECID": "Si105000BC2", "SHORTNAME": "Si", "ASSETCODE": "Si", "UNDERLYINGASSET": "SiH2", "OPTIONTYPE": "C", "STRIKE": 105000, "LASTTRADEDATE": "17.03.2022", "PREVSETTLEPRICE": 4450}`
+	s := OptionSeries{}
+	if err := parseOptionSeries([]byte(incomeJSON), &s); err == nil {
+		t.Fatalf("Error: expecting an error \ngot <nil> \ninstead")
+	}
+}
+
+func TestParseOptionSeriesResponseNilError(t *testing.T) {
+	var series *[]OptionSeries = nil
+	if got, expected := parseOptionSeriesResponse([]byte(``), series), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	var futures *[]FuturesSeries = nil
+	if got, expected := parseFuturesSeriesResponse([]byte(``), futures), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseOptionMarketDataUnexpectedDataTypeError(t *testing.T) {
+	if got, expected := parseOptionMarketData([]byte(`[[]]`), nil), ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestDerivativesService_GetFuturesSeries(t *testing.T) {
+	srv := getDerivativesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.Derivatives.GetFuturesSeries(context.Background(), "si", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Series), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := result.Series[1].SecurityId, "SiM2"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestDerivativesService_GetOptionSeries(t *testing.T) {
+	srv := getDerivativesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.Derivatives.GetOptionSeries(context.Background(), "Si", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Series), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	expectedFirst := OptionSeries{
+		SecurityId:      "Si100000BC2",
+		ShortName:       "Si-3.22M170322CA100000",
+		AssetCode:       "Si",
+		UnderlyingAsset: "SiH2",
+		Type:            OptionTypeCall,
+		Strike:          100000,
+		Expiration:      time.Date(2022, 3, 17, 0, 0, 0, 0, MoscowLocation),
+		PrevSettlePrice: 7640,
+		TheorPrice:      7702,
+		Volatility:      60.12,
+		OpenPosition:    12034,
+	}
+	if got, expected := result.Series[0], expectedFirst; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	expirations := result.Expirations()
+	if got, expected := len(expirations), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v expirations\ngot:\n %v expirations\ninstead", expected, got)
+	}
+	if got, expected := expirations[1], time.Date(2022, 6, 16, 0, 0, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestDerivativesService_GetOptionBoard(t *testing.T) {
+	srv := getDerivativesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	board, err := c.Derivatives.GetOptionBoard(context.Background(), "Si", time.Date(2022, 3, 17, 12, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(board.Rows), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v rows\ngot:\n %v rows\ninstead", expected, got)
+	}
+	strikes := []float64{100000, 105000, 110000}
+	for i, row := range board.Rows {
+		if got, expected := row.Strike, strikes[i]; got != expected {
+			t.Fatalf("Error: expecting: \n %v strike\ngot:\n %v strike\ninstead", expected, got)
+		}
+	}
+	if board.Rows[0].Put != nil || board.Rows[2].Call != nil {
+		t.Fatalf("Error: expecting <nil> put of the first row and <nil> call of the last row")
+	}
+	if got, expected := board.Rows[1].Put.OpenPosition, int64(38840); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	// null market data values are zero
+	if got, expected := board.Rows[2].Put.TheorPrice, 0.0; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+
+	// the date is taken in its own location even if it's another date in MoscowLocation
+	board, err = c.Derivatives.GetOptionBoard(context.Background(), "Si", time.Date(2022, 3, 17, 20, 0, 0, 0, time.FixedZone("UTC-10", -10*60*60)), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(board.Rows), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v rows\ngot:\n %v rows\ninstead", expected, got)
+	}
+
+	board, err = c.Derivatives.GetOptionBoard(context.Background(), "Si", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(board.Rows), 0; got != expected {
+		t.Fatalf("Error: expecting: \n %v rows\ngot:\n %v rows\ninstead", expected, got)
+	}
+}
+
+func TestDerivativesService_BadAsset(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.Derivatives.GetFuturesSeries(context.Background(), "", nil); err != ErrBadSecurityParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadSecurityParameter, err)
+	}
+	if _, err := c.Derivatives.GetOptionBoard(context.Background(), "", time.Now(), nil); err != ErrBadSecurityParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadSecurityParameter, err)
+	}
+}
+
+func TestDerivativesService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.Derivatives.GetOptionSeries(context.Background(), "Si", nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+	if _, err := c.Derivatives.GetFuturesSeries(context.Background(), "Si", nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestDerivativesNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	if _, err := c.Derivatives.GetFuturesSeries(ctx, "Si", nil); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
@@ -0,0 +1,365 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path"
+	"unicode/utf8"
+)
+
+// BoardInfo struct represents a board of the market with its parameters.
+// Decimals and CurrencyId are zero values if MoEx ISS API doesn't provide them.
+type BoardInfo struct {
+	Board             // "id", "board_group_id", "boardid", "title", "is_traded"
+	Decimals   int64  // "decimals" Number of decimal places of prices
+	CurrencyId string // "currencyid" Currency of prices
+}
+
+const (
+	enginesPartsUrl     = "engines.json"
+	enginesMarketsUrl   = "markets.json"
+	enginesBoardsUrl    = "boards.json"
+	enginesBoardGrpsUrl = "boardgroups.json"
+
+	enginesEndpoint     = "engines"
+	marketsEndpoint     = "engines/{engine}/markets"
+	boardsEndpoint      = "engines/{engine}/markets/{market}/boards"
+	boardGroupsEndpoint = "engines/{engine}/markets/{market}/boardgroups"
+
+	enginesKeyEngine      = "engine"
+	enginesKeyMarkets     = "markets"
+	enginesKeyBoards      = "boards"
+	enginesKeyBoardGroups = "boardgroups"
+
+	enginesKeyId               = "id"
+	enginesKeyName             = "name"
+	enginesKeyMarketName       = "NAME"
+	enginesKeyTitle            = "title"
+	enginesKeyBoardGroupId     = "board_group_id"
+	enginesKeyBoardId          = "boardid"
+	enginesKeyIsTraded         = "is_traded"
+	enginesKeyDecimals         = "decimals"
+	enginesKeyCurrencyId       = "currencyid"
+	enginesKeyTradeEngineId    = "trade_engine_id"
+	enginesKeyTradeEngineName  = "trade_engine_name"
+	enginesKeyTradeEngineTitle = "trade_engine_title"
+	enginesKeyMarketId         = "market_id"
+	enginesKeyMarketNameLower  = "market_name"
+	enginesKeyIsDefault        = "is_default"
+)
+
+// EnginesService gets engines, markets of an engine, boards and board groups of a market
+// from the MoEx ISS API. It's a targeted alternative to IndexService.List.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/engines
+// https://iss.moex.com/iss/engines/[engine]/markets
+// https://iss.moex.com/iss/engines/[engine]/markets/[market]/boards
+// https://iss.moex.com/iss/engines/[engine]/markets/[market]/boardgroups
+type EnginesService service
+
+// List provides a list of engines of MoEx ISS
+func (e *EnginesService) List(ctx context.Context, opt *EnginesRequestOptions) ([]Engine, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	url, _ := e.client.BaseURL.Parse(enginesPartsUrl)
+	b, err := e.get(ctx, enginesEndpoint, addEnginesRequestOptions(url, opt).String())
+	if err != nil {
+		return nil, err
+	}
+	engines := make([]Engine, 0)
+	err = parseBlockWithCursor(b, enginesKeyEngine, nil, func(data []byte) error {
+		engine := Engine{}
+		errParse := parseEnginesEngine(data, &engine)
+		if errParse == nil {
+			engines = append(engines, engine)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return engines, nil
+}
+
+// Markets provides a list of markets of the engine
+func (e *EnginesService) Markets(ctx context.Context, engine EngineName, opt *EnginesRequestOptions) ([]Market, error) {
+	url, err := e.getUrl(engine, "", enginesMarketsUrl, opt)
+	if err != nil {
+		return nil, err
+	}
+	b, err := e.get(ctx, marketsEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+	markets := make([]Market, 0)
+	err = parseBlockWithCursor(b, enginesKeyMarkets, nil, func(data []byte) error {
+		market := Market{}
+		errParse := parseEnginesMarket(data, &market)
+		if errParse == nil {
+			market.Engine.Name = engine.String()
+			markets = append(markets, market)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return markets, nil
+}
+
+// Boards provides a list of boards of the market with their parameters
+func (e *EnginesService) Boards(ctx context.Context, engine EngineName, market string, opt *EnginesRequestOptions) ([]BoardInfo, error) {
+	url, err := e.getUrl(engine, market, enginesBoardsUrl, opt)
+	if err != nil {
+		return nil, err
+	}
+	b, err := e.get(ctx, boardsEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+	boards := make([]BoardInfo, 0)
+	err = parseBlockWithCursor(b, enginesKeyBoards, nil, func(data []byte) error {
+		board := BoardInfo{}
+		errParse := parseEnginesBoard(data, &board)
+		if errParse == nil {
+			boards = append(boards, board)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// BoardGroups provides a list of board groups of the market
+func (e *EnginesService) BoardGroups(ctx context.Context, engine EngineName, market string, opt *EnginesRequestOptions) ([]BoardGroup, error) {
+	url, err := e.getUrl(engine, market, enginesBoardGrpsUrl, opt)
+	if err != nil {
+		return nil, err
+	}
+	b, err := e.get(ctx, boardGroupsEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+	boardGroups := make([]BoardGroup, 0)
+	err = parseBlockWithCursor(b, enginesKeyBoardGroups, nil, func(data []byte) error {
+		bg := BoardGroup{}
+		errParse := parseEnginesBoardGroup(data, &bg)
+		if errParse == nil {
+			boardGroups = append(boardGroups, bg)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	return boardGroups, nil
+}
+
+func (e *EnginesService) get(ctx context.Context, endpoint string, url string) ([]byte, error) {
+	req, err := e.client.newEndpointRequest(endpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = e.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// getUrl provides an url for a request of markets of the engine
+// or of boards and board groups of the market if 'market' isn't empty
+// opt *EnginesRequestOptions can be nil, it is safe
+func (e *EnginesService) getUrl(engine EngineName, market string, fileName string, opt *EnginesRequestOptions) (string, error) {
+	if engine == EngineUndefined {
+		return "", ErrBadEngineParameter
+	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
+	url, _ := e.client.BaseURL.Parse(enginePartOfPath)
+	if fileName == enginesMarketsUrl {
+		url.Path = path.Join(url.Path, engine.String(), fileName)
+	} else {
+		marketMinLen := 3
+		if market == "" || utf8.RuneCountInString(market) < marketMinLen {
+			return "", ErrBadMarketParameter
+		}
+		url.Path = path.Join(url.Path, engine.String(), marketsPartOfPath, market, fileName)
+	}
+	gotURL := addEnginesRequestOptions(url, opt)
+	return gotURL.String(), nil
+}
+
+func parseEnginesEngine(data []byte, engine *Engine) (err error) {
+	id, err := parseIntWithDefaultValue(data, enginesKeyId)
+	if err != nil {
+		return
+	}
+
+	name, err := parseStringWithDefaultValueByKey(data, enginesKeyName, "")
+	if err != nil {
+		return
+	}
+
+	title, err := parseStringWithDefaultValueByKey(data, enginesKeyTitle, "")
+	if err != nil {
+		return
+	}
+
+	engine.Id = id
+	engine.Name = name
+	engine.Title = title
+
+	return
+}
+
+func parseEnginesMarket(data []byte, market *Market) (err error) {
+	id, err := parseIntWithDefaultValue(data, enginesKeyId)
+	if err != nil {
+		return
+	}
+
+	name, err := parseStringWithDefaultValueByKey(data, enginesKeyMarketName, "")
+	if err != nil {
+		return
+	}
+
+	title, err := parseStringWithDefaultValueByKey(data, enginesKeyTitle, "")
+	if err != nil {
+		return
+	}
+
+	market.Id = id
+	market.Name = name
+	market.Title = title
+
+	return
+}
+
+func parseEnginesBoard(data []byte, board *BoardInfo) (err error) {
+	id, err := parseIntWithDefaultValue(data, enginesKeyId)
+	if err != nil {
+		return
+	}
+
+	boardGroupId, err := parseIntWithDefaultValue(data, enginesKeyBoardGroupId)
+	if err != nil {
+		return
+	}
+
+	boardId, err := parseStringWithDefaultValueByKey(data, enginesKeyBoardId, "")
+	if err != nil {
+		return
+	}
+
+	title, err := parseStringWithDefaultValueByKey(data, enginesKeyTitle, "")
+	if err != nil {
+		return
+	}
+
+	isTraded, err := parseIntWithDefaultValue(data, enginesKeyIsTraded)
+	if err != nil {
+		return
+	}
+
+	var decimals int64
+	if hasKey(data, enginesKeyDecimals) {
+		decimals, err = parseIntWithDefaultValue(data, enginesKeyDecimals)
+		if err != nil {
+			return
+		}
+	}
+
+	var currencyId string
+	if hasKey(data, enginesKeyCurrencyId) {
+		currencyId, err = parseStringWithDefaultValueByKey(data, enginesKeyCurrencyId, "")
+		if err != nil {
+			return
+		}
+	}
+
+	board.Id = id
+	board.BoardGroupId = boardGroupId
+	board.BoardId = boardId
+	board.BoardTitle = title
+	board.IsTraded = isTraded == 1
+	board.Decimals = decimals
+	board.CurrencyId = currencyId
+
+	return
+}
+
+func parseEnginesBoardGroup(data []byte, bg *BoardGroup) (err error) {
+	id, err := parseIntWithDefaultValue(data, enginesKeyId)
+	if err != nil {
+		return
+	}
+
+	engineId, err := parseIntWithDefaultValue(data, enginesKeyTradeEngineId)
+	if err != nil {
+		return
+	}
+
+	engineName, err := parseStringWithDefaultValueByKey(data, enginesKeyTradeEngineName, "")
+	if err != nil {
+		return
+	}
+
+	engineTitle, err := parseStringWithDefaultValueByKey(data, enginesKeyTradeEngineTitle, "")
+	if err != nil {
+		return
+	}
+
+	marketId, err := parseIntWithDefaultValue(data, enginesKeyMarketId)
+	if err != nil {
+		return
+	}
+
+	marketName, err := parseStringWithDefaultValueByKey(data, enginesKeyMarketNameLower, "")
+	if err != nil {
+		return
+	}
+
+	name, err := parseStringWithDefaultValueByKey(data, enginesKeyName, "")
+	if err != nil {
+		return
+	}
+
+	title, err := parseStringWithDefaultValueByKey(data, enginesKeyTitle, "")
+	if err != nil {
+		return
+	}
+
+	isDefault, err := parseIntWithDefaultValue(data, enginesKeyIsDefault)
+	if err != nil {
+		return
+	}
+
+	isTraded, err := parseIntWithDefaultValue(data, enginesKeyIsTraded)
+	if err != nil {
+		return
+	}
+
+	bg.Id = id
+	bg.Engine.Id = engineId
+	bg.Engine.Name = engineName
+	bg.Engine.Title = engineTitle
+	bg.MarketId = marketId
+	bg.MarketName = marketName
+	bg.Name = name
+	bg.Title = title
+	bg.IsDefault = isDefault == 1
+	bg.IsTraded = isTraded == 1
+
+	return
+}
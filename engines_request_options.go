@@ -0,0 +1,73 @@
+package moexiss
+
+import "net/url"
+
+// EnginesRequestOptions contains options which can be used as arguments
+// for building requests to get engines, markets, boards and board groups.
+// MoEx ISS API endpoints:
+//
+// https://iss.moex.com/iss/engines
+// https://iss.moex.com/iss/engines/[engine]/markets
+// https://iss.moex.com/iss/engines/[engine]/markets/[market]/boards
+// https://iss.moex.com/iss/engines/[engine]/markets/[market]/boardgroups
+type EnginesRequestOptions struct {
+	lang Language // `lang` query parameter in url.URL
+}
+
+// EnginesReqOptionsBuilder represents a builder of EnginesRequestOptions struct
+type EnginesReqOptionsBuilder struct {
+	options *EnginesRequestOptions
+}
+
+// NewEnginesReqOptionsBuilder is a constructor of EnginesReqOptionsBuilder
+func NewEnginesReqOptionsBuilder() *EnginesReqOptionsBuilder {
+	return &EnginesReqOptionsBuilder{options: &EnginesRequestOptions{}}
+}
+
+// Build builds EnginesRequestOptions from EnginesReqOptionsBuilder
+func (b *EnginesReqOptionsBuilder) Build() *EnginesRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds EnginesRequestOptions from EnginesReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *EnginesReqOptionsBuilder) BuildValidated() (*EnginesRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of EnginesRequestOptions,
+// options can be nil, it is safe
+func (options *EnginesRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *EnginesReqOptionsBuilder) Lang(lang Language) *EnginesReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// addEnginesRequestOptions sets parameters into *url.URL
+// from EnginesRequestOptions struct and returns it back
+func addEnginesRequestOptions(url *url.URL, options *EnginesRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
@@ -0,0 +1,61 @@
+package moexiss
+
+import (
+	"testing"
+)
+
+func TestEnginesReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := EnginesRequestOptions{}
+	bld := NewEnginesReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` EnginesRequestOptions \ngot `%v` EnginesRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestEnginesReqOptionsBuilder_Lang(t *testing.T) {
+	expectStruct := EnginesRequestOptions{lang: LangEn}
+	bld := NewEnginesReqOptionsBuilder()
+	bld.Lang(LangEn)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewEnginesRequestOptions(t *testing.T) {
+	expectStruct := EnginesRequestOptions{
+		lang: LangRu,
+	}
+	bld := NewEnginesReqOptionsBuilder()
+	bld.Lang(LangRu)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+
+}
+
+func TestAddEnginesRequestOptionsNilOptions(t *testing.T) {
+	var income *EnginesRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addEnginesRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddEnginesRequestOptions(t *testing.T) {
+	var incomeOptions = NewEnginesReqOptionsBuilder().
+		Lang(LangEn).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addEnginesRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&lang=en`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
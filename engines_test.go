@@ -0,0 +1,224 @@
+package moexiss
+
+import (
+	"context"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func getEnginesSrv(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fileName string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/boardgroups.json"):
+			fileName = "engine_boardgroups.json"
+		case strings.HasSuffix(r.URL.Path, "/boards.json"):
+			fileName = "engine_boards.json"
+		case strings.HasSuffix(r.URL.Path, "/markets.json"):
+			fileName = "engine_markets.json"
+		default:
+			fileName = "engines.json"
+		}
+		byteValue, err := getTestingData(fileName)
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+}
+
+func TestEnginesGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	type Case struct {
+		engine   EngineName
+		market   string
+		fileName string
+		expected string
+	}
+	cases := []Case{
+		{EngineStock, "", enginesMarketsUrl, `https://iss.moex.com/iss/engines/stock/markets.json?iss.json=extended&iss.meta=off`},
+		{EngineStock, "shares", enginesBoardsUrl, `https://iss.moex.com/iss/engines/stock/markets/shares/boards.json?iss.json=extended&iss.meta=off`},
+		{EngineFutures, "forts", enginesBoardGrpsUrl, `https://iss.moex.com/iss/engines/futures/markets/forts/boardgroups.json?iss.json=extended&iss.meta=off`},
+	}
+	for i, c1 := range cases {
+		got, err := c.Engines.getUrl(c1.engine, c1.market, c1.fileName, nil)
+		if err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead in %d case", err, i)
+		}
+		if got != c1.expected {
+			t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead in %d case", c1.expected, got, i)
+		}
+	}
+}
+
+func TestEnginesGetUrlBadParams(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.Engines.getUrl(EngineUndefined, "shares", enginesBoardsUrl, nil); err != ErrBadEngineParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadEngineParameter, err)
+	}
+	if _, err := c.Engines.getUrl(EngineStock, "sh", enginesBoardsUrl, nil); err != ErrBadMarketParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadMarketParameter, err)
+	}
+}
+
+func TestParseEnginesBoard(t *testing.T) {
+	expectedStruct := BoardInfo{
+		Board: Board{
+			Id:           129,
+			BoardGroupId: 57,
+			BoardId:      "TQBR",
+			BoardTitle:   "Т+: Акции и ДР - безадрес.",
+			IsTraded:     true,
+		},
+		Decimals:   2,
+		CurrencyId: "SUR",
+	}
+	var incomeJSON = `
+{"id": 129, "board_group_id": 57, "boardid": "TQBR", "title": "Т+: Акции и ДР - безадрес.", "is_traded": 1, "decimals": 2, "currencyid": "SUR"}
+`
+	b := BoardInfo{}
+	if err := parseEnginesBoard([]byte(incomeJSON), &b); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := b, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseEnginesItemsErrCases(t *testing.T) {
+	if got, expected := parseEnginesEngine([]byte(`{"id": 1, "title": "stock"}`), &Engine{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseEnginesMarket([]byte(`{"id": 1, "name": "shares", "title": "Рынок акций"}`), &Market{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseEnginesBoard([]byte(`{"id": 129, "boardid": "TQBR", "title": "Т+", "is_traded": 1}`), &BoardInfo{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseEnginesBoard([]byte(`{"id": 129, "board_group_id": 57, "boardid": "TQBR", "title": "Т+", "is_traded": 1, "decimals": 2.5}`), &BoardInfo{}), jsonparser.MalformedValueError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseEnginesBoardGroup([]byte(`{"id": 57, "trade_engine_id": 1}`), &BoardGroup{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestEnginesService_List(t *testing.T) {
+	srv := getEnginesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	engines, err := c.Engines.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(engines), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	expectedEngine := Engine{GeneralFields{Id: 4, Name: "futures", Title: "Срочный рынок"}}
+	if got, expected := engines[3], expectedEngine; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestEnginesService_Markets(t *testing.T) {
+	srv := getEnginesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	markets, err := c.Engines.Markets(context.Background(), EngineStock, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(markets), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := markets[1].Name, "shares"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := markets[1].Engine.Name, "stock"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestEnginesService_Boards(t *testing.T) {
+	srv := getEnginesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	boards, err := c.Engines.Boards(context.Background(), EngineStock, "shares", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(boards), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := boards[2].CurrencyId, "USD"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	// parameters are optional
+	if got, expected := boards[3].Decimals, int64(0); got != expected || boards[3].IsTraded {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestEnginesService_BoardGroups(t *testing.T) {
+	srv := getEnginesSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	boardGroups, err := c.Engines.BoardGroups(context.Background(), EngineStock, "shares", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(boardGroups), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	bg := boardGroups[0]
+	if bg.Name != "stock_shares_tplus" || bg.MarketName != "shares" || bg.Engine.Id != 1 || !bg.IsDefault || !bg.IsTraded {
+		t.Fatalf("Error: unexpected board group: \n %v", bg)
+	}
+}
+
+func TestEnginesService_BadParams(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.Engines.Markets(context.Background(), EngineUndefined, nil); err != ErrBadEngineParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadEngineParameter, err)
+	}
+	if _, err := c.Engines.Boards(context.Background(), EngineStock, "", nil); err != ErrBadMarketParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadMarketParameter, err)
+	}
+	if _, err := c.Engines.BoardGroups(context.Background(), EngineUndefined, "shares", nil); err != ErrBadEngineParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadEngineParameter, err)
+	}
+}
+
+func TestEnginesService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.Engines.List(context.Background(), nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+	if _, err := c.Engines.Boards(context.Background(), EngineStock, "shares", nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestEnginesNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	if _, err := c.Engines.List(ctx, nil); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
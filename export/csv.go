@@ -0,0 +1,84 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVOptions contains options which can be used to tune CSVWriter.
+// A nil *CSVOptions is safe and means default options.
+type CSVOptions struct {
+	Delimiter  rune // a field delimiter, ',' by default
+	SkipHeader bool // don't write a header line with column names
+	UseCRLF    bool // use \r\n as a line terminator
+}
+
+// CSVWriter writes tables into CSV records.
+// Write can be called several times to stream a large result by chunks,
+// the header is written only once.
+type CSVWriter struct {
+	w             *csv.Writer
+	columns       []string
+	skipHeader    bool
+	headerWritten bool
+}
+
+// NewCSVWriter is a constructor of CSVWriter
+// opt *CSVOptions can be nil, it is safe
+func NewCSVWriter(w io.Writer, opt *CSVOptions) *CSVWriter {
+	cw := &CSVWriter{w: csv.NewWriter(w)}
+	if opt == nil {
+		return cw
+	}
+	if opt.Delimiter != 0 {
+		cw.w.Comma = opt.Delimiter
+	}
+	cw.w.UseCRLF = opt.UseCRLF
+	cw.skipHeader = opt.SkipHeader
+	return cw
+}
+
+// Write writes all rows of the table and flushes them to the underlying io.Writer.
+// All the tables passed to the same CSVWriter must have the same columns.
+func (cw *CSVWriter) Write(t Table) error {
+	if t == nil {
+		return ErrNilTable
+	}
+	err := cw.writeHeader(t.Columns())
+	if err != nil {
+		return err
+	}
+	record := make([]string, len(cw.columns))
+	for i := 0; i < t.Len(); i++ {
+		for k, value := range t.Row(i) {
+			record[k] = FormatValue(value)
+		}
+		err = cw.w.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *CSVWriter) writeHeader(columns []string) error {
+	if cw.columns != nil {
+		if !equalColumns(cw.columns, columns) {
+			return ErrColumnsMismatch
+		}
+		return nil
+	}
+	cw.columns = columns
+	if cw.skipHeader || cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+	return cw.w.Write(columns)
+}
+
+// WriteCSV writes the table into w as CSV
+// opt *CSVOptions can be nil, it is safe
+func WriteCSV(w io.Writer, t Table, opt *CSVOptions) error {
+	return NewCSVWriter(w, opt).Write(t)
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bytes"
+	"github.com/dimakoz/moexiss"
+	"testing"
+)
+
+var testTurnovers = []moexiss.Turnover{
+	{Name: "stock", Id: 1, ValToday: 1988404.90786, ValTodayUsd: 26876.4019428, NumTrades: 2214956, UpdateTime: "2021-02-24 23:50:29", Title: "Securities Market"},
+	{Name: "currency", Id: 3, ValToday: 1517369.23013, ValTodayUsd: 20509.6181183, NumTrades: 481765, UpdateTime: "2021-02-24 23:49:59", Title: "FX Market"},
+}
+
+func TestWriteCSV(t *testing.T) {
+	expected := "NAME,ID,VALTODAY,VALTODAY_USD,NUMTRADES,UPDATETIME,TITLE\n" +
+		"stock,1,1988404.90786,26876.4019428,2214956,2021-02-24 23:50:29,Securities Market\n" +
+		"currency,3,1517369.23013,20509.6181183,481765,2021-02-24 23:49:59,FX Market\n"
+	var b bytes.Buffer
+	err := WriteCSV(&b, Turnovers(testTurnovers), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got := b.String(); got != expected {
+		t.Fatalf("Error: expecting: \n%s \ngot:\n%s \ninstead", expected, got)
+	}
+}
+
+func TestWriteCSVOptions(t *testing.T) {
+	expected := "stock;1;1988404.90786;26876.4019428;2214956;2021-02-24 23:50:29;Securities Market\r\n"
+	var b bytes.Buffer
+	opt := &CSVOptions{Delimiter: ';', SkipHeader: true, UseCRLF: true}
+	err := WriteCSV(&b, Turnovers(testTurnovers[:1]), opt)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got := b.String(); got != expected {
+		t.Fatalf("Error: expecting: \n%s \ngot:\n%s \ninstead", expected, got)
+	}
+}
+
+func TestCSVWriterStreaming(t *testing.T) {
+	var b bytes.Buffer
+	w := NewCSVWriter(&b, nil)
+	for _, chunk := range [][]moexiss.Turnover{testTurnovers[:1], testTurnovers[1:]} {
+		if err := w.Write(Turnovers(chunk)); err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+	}
+	if got, expected := bytes.Count(b.Bytes(), []byte("\n")), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v lines\ngot:\n %v lines\ninstead", expected, got)
+	}
+}
+
+func TestCSVWriterColumnsMismatch(t *testing.T) {
+	var b bytes.Buffer
+	w := NewCSVWriter(&b, nil)
+	if err := w.Write(Turnovers(testTurnovers)); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := w.Write(Aggregates(nil)), ErrColumnsMismatch; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestCSVWriterNilTable(t *testing.T) {
+	var b bytes.Buffer
+	if got, expected := WriteCSV(&b, nil, nil), ErrNilTable; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
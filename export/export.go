@@ -0,0 +1,25 @@
+// Package export writes slices of moexiss response structs
+// as CSV, JSON or newline-delimited JSON.
+// Column names match the keys of MoEx ISS API, e.g. moexiss.SecStat.Last is written as "LAST".
+package export
+
+import "errors"
+
+// A section of errors of the export package
+var (
+	ErrNilTable        = errors.New("nil table")
+	ErrColumnsMismatch = errors.New("columns of the table don't match the columns written before")
+)
+
+// equalColumns reports whether a and b contain the same column names in the same order
+func equalColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if b[i] != v {
+			return false
+		}
+	}
+	return true
+}
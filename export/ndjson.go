@@ -0,0 +1,118 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// NDJSONWriter writes tables as newline-delimited JSON,
+// one JSON object per a row with keys in the order of the table columns.
+// Write can be called several times to stream a large result by chunks.
+type NDJSONWriter struct {
+	w       *bufio.Writer
+	columns []string
+	keys    []string
+}
+
+// NewNDJSONWriter is a constructor of NDJSONWriter
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{w: bufio.NewWriter(w)}
+}
+
+// Write writes all rows of the table and flushes them to the underlying io.Writer.
+// All the tables passed to the same NDJSONWriter must have the same columns.
+func (nw *NDJSONWriter) Write(t Table) error {
+	if t == nil {
+		return ErrNilTable
+	}
+	columns := t.Columns()
+	if nw.columns == nil {
+		keys, err := encodeKeys(columns)
+		if err != nil {
+			return err
+		}
+		nw.columns = columns
+		nw.keys = keys
+	} else if !equalColumns(nw.columns, columns) {
+		return ErrColumnsMismatch
+	}
+	for i := 0; i < t.Len(); i++ {
+		err := nw.writeRow(t.Row(i))
+		if err != nil {
+			return err
+		}
+	}
+	return nw.w.Flush()
+}
+
+func (nw *NDJSONWriter) writeRow(row []interface{}) error {
+	if err := writeObject(nw.w, nw.keys, row); err != nil {
+		return err
+	}
+	return nw.w.WriteByte('\n')
+}
+
+// writeObject writes the row as a JSON object with the keys in the order of the columns
+func writeObject(w *bufio.Writer, keys []string, row []interface{}) error {
+	_ = w.WriteByte('{')
+	for k, value := range row {
+		if k > 0 {
+			_ = w.WriteByte(',')
+		}
+		_, _ = w.WriteString(keys[k])
+		_ = w.WriteByte(':')
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, _ = w.Write(b)
+	}
+	return w.WriteByte('}')
+}
+
+// encodeKeys returns JSON encoded column names
+func encodeKeys(columns []string) ([]string, error) {
+	keys := make([]string, len(columns))
+	for i, column := range columns {
+		b, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = string(b)
+	}
+	return keys, nil
+}
+
+// WriteNDJSON writes the table into w as newline-delimited JSON
+func WriteNDJSON(w io.Writer, t Table) error {
+	return NewNDJSONWriter(w).Write(t)
+}
+
+// WriteJSON writes the table into w as a JSON array of objects, an object per a row
+// with keys in the order of the table columns, the same as WriteNDJSON does
+func WriteJSON(w io.Writer, t Table) error {
+	if t == nil {
+		return ErrNilTable
+	}
+	keys, err := encodeKeys(t.Columns())
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	_ = bw.WriteByte('[')
+	for i := 0; i < t.Len(); i++ {
+		if i > 0 {
+			_ = bw.WriteByte(',')
+		}
+		_ = bw.WriteByte('\n')
+		if err = writeObject(bw, keys, t.Row(i)); err != nil {
+			return err
+		}
+	}
+	if t.Len() > 0 {
+		_ = bw.WriteByte('\n')
+	}
+	_, _ = bw.WriteString("]\n")
+	return bw.Flush()
+}
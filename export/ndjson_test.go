@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bytes"
+	"github.com/dimakoz/moexiss"
+	"testing"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	expected := `{"NAME":"stock","ID":1,"VALTODAY":1988404.90786,"VALTODAY_USD":26876.4019428,"NUMTRADES":2214956,"UPDATETIME":"2021-02-24 23:50:29","TITLE":"Securities Market"}` + "\n" +
+		`{"NAME":"currency","ID":3,"VALTODAY":1517369.23013,"VALTODAY_USD":20509.6181183,"NUMTRADES":481765,"UPDATETIME":"2021-02-24 23:49:59","TITLE":"FX Market"}` + "\n"
+	var b bytes.Buffer
+	err := WriteNDJSON(&b, Turnovers(testTurnovers))
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got := b.String(); got != expected {
+		t.Fatalf("Error: expecting: \n%s \ngot:\n%s \ninstead", expected, got)
+	}
+}
+
+func TestNDJSONWriterStreaming(t *testing.T) {
+	var b bytes.Buffer
+	w := NewNDJSONWriter(&b)
+	for _, chunk := range [][]moexiss.Turnover{testTurnovers[:1], testTurnovers[1:]} {
+		if err := w.Write(Turnovers(chunk)); err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+	}
+	if got, expected := bytes.Count(b.Bytes(), []byte("\n")), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v lines\ngot:\n %v lines\ninstead", expected, got)
+	}
+	if got, expected := w.Write(SecStats(nil)), ErrColumnsMismatch; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestNDJSONWriterNilTable(t *testing.T) {
+	var b bytes.Buffer
+	if got, expected := WriteNDJSON(&b, nil), ErrNilTable; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	expected := "[\n" +
+		`{"NAME":"stock","ID":1,"VALTODAY":1988404.90786,"VALTODAY_USD":26876.4019428,"NUMTRADES":2214956,"UPDATETIME":"2021-02-24 23:50:29","TITLE":"Securities Market"}` + ",\n" +
+		`{"NAME":"currency","ID":3,"VALTODAY":1517369.23013,"VALTODAY_USD":20509.6181183,"NUMTRADES":481765,"UPDATETIME":"2021-02-24 23:49:59","TITLE":"FX Market"}` + "\n]\n"
+	var b bytes.Buffer
+	if err := WriteJSON(&b, Turnovers(testTurnovers)); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got := b.String(); got != expected {
+		t.Fatalf("Error: expecting: \n%s \ngot:\n%s \ninstead", expected, got)
+	}
+	b.Reset()
+	if err := WriteJSON(&b, Turnovers(nil)); err != nil || b.String() != "[]\n" {
+		t.Fatalf("Error: expecting an empty array \ngot %q, %v \ninstead", b.String(), err)
+	}
+	if got, expected := WriteJSON(&b, nil), ErrNilTable; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,445 @@
+package export
+
+import (
+	"fmt"
+	"github.com/dimakoz/moexiss"
+	"strconv"
+)
+
+// Table represents a tabular view of a slice of MoEx ISS response structs.
+// Column names match the keys of MoEx ISS API.
+type Table interface {
+	// Columns returns the column names of the table
+	Columns() []string
+	// Len returns the number of rows of the table
+	Len() int
+	// Row returns values of the i-th row in the order of Columns
+	Row(i int) []interface{}
+}
+
+// SecStats returns Table of moexiss.SecStat items
+func SecStats(items []moexiss.SecStat) Table {
+	return secStatTable(items)
+}
+
+type secStatTable []moexiss.SecStat
+
+var secStatColumns = []string{
+	"SECID",
+	"BOARDID",
+	"TRADINGSESSION",
+	"TIME",
+	"PRICEMINUSPREVWAPRICE",
+	"VOLTODAY",
+	"VALTODAY",
+	"HIGHBID",
+	"LOWOFFER",
+	"LASTOFFER",
+	"LASTBID",
+	"OPEN",
+	"LOW",
+	"HIGH",
+	"LAST",
+	"LCLOSEPRICE",
+	"NUMTRADES",
+	"WAPRICE",
+	"ADMITTEDQUOTE",
+	"MARKETPRICE2",
+	"LCURRENTPRICE",
+	"CLOSINGAUCTIONPRICE",
+}
+
+func (t secStatTable) Columns() []string { return secStatColumns }
+func (t secStatTable) Len() int          { return len(t) }
+func (t secStatTable) Row(i int) []interface{} {
+	s := t[i]
+	return []interface{}{
+		s.Ticker,
+		s.BoardId,
+		s.TrSession.String(),
+		s.Time,
+		s.PriceMinusPrevPr,
+		s.VolToday,
+		s.ValToday,
+		s.HighBid,
+		s.LowOffer,
+		s.LastOffer,
+		s.LastBid,
+		s.Open,
+		s.Low,
+		s.High,
+		s.Last,
+		s.LClosePrice,
+		s.NumTrades,
+		s.WaPrice,
+		s.AdmittedQuote,
+		s.MarketPrice,
+		s.LCurrentPrice,
+		s.ClosingAucPrice,
+	}
+}
+
+// Listings returns Table of moexiss.Listing items
+func Listings(items []moexiss.Listing) Table {
+	return listingTable(items)
+}
+
+type listingTable []moexiss.Listing
+
+var listingColumns = []string{
+	"SECID",
+	"SHORTNAME",
+	"NAME",
+	"BOARDID",
+	"decimals",
+	"history_from",
+	"history_till",
+}
+
+func (t listingTable) Columns() []string { return listingColumns }
+func (t listingTable) Len() int          { return len(t) }
+func (t listingTable) Row(i int) []interface{} {
+	l := t[i]
+	return []interface{}{l.Ticker, l.ShortName, l.FullName, l.BoardId, l.Decimals, l.From, l.Till}
+}
+
+// Aggregates returns Table of moexiss.Aggregate items
+func Aggregates(items []moexiss.Aggregate) Table {
+	return aggregateTable(items)
+}
+
+type aggregateTable []moexiss.Aggregate
+
+var aggregateColumns = []string{
+	"market_name",
+	"market_title",
+	"engine",
+	"tradedate",
+	"secid",
+	"value",
+	"volume",
+	"numtrades",
+	"updated_at",
+}
+
+func (t aggregateTable) Columns() []string { return aggregateColumns }
+func (t aggregateTable) Len() int          { return len(t) }
+func (t aggregateTable) Row(i int) []interface{} {
+	a := t[i]
+	return []interface{}{
+		a.MarketName,
+		a.MarketTitle,
+		a.Engine,
+		a.TradeDate,
+		a.SecurityId,
+		a.Value,
+		a.Volume,
+		a.NumberTrades,
+		a.UpdatedAt,
+	}
+}
+
+// Turnovers returns Table of moexiss.Turnover items
+func Turnovers(items []moexiss.Turnover) Table {
+	return turnoverTable(items)
+}
+
+type turnoverTable []moexiss.Turnover
+
+var turnoverColumns = []string{
+	"NAME",
+	"ID",
+	"VALTODAY",
+	"VALTODAY_USD",
+	"NUMTRADES",
+	"UPDATETIME",
+	"TITLE",
+}
+
+func (t turnoverTable) Columns() []string { return turnoverColumns }
+func (t turnoverTable) Len() int          { return len(t) }
+func (t turnoverTable) Row(i int) []interface{} {
+	tr := t[i]
+	return []interface{}{tr.Name, tr.Id, tr.ValToday, tr.ValTodayUsd, tr.NumTrades, tr.UpdateTime, tr.Title}
+}
+
+// Indices returns Table of moexiss.Indices items
+func Indices(items []moexiss.Indices) Table {
+	return indicesTable(items)
+}
+
+type indicesTable []moexiss.Indices
+
+var indicesColumns = []string{"SECID", "SHORTNAME", "FROM", "TILL"}
+
+func (t indicesTable) Columns() []string { return indicesColumns }
+func (t indicesTable) Len() int          { return len(t) }
+func (t indicesTable) Row(i int) []interface{} {
+	ind := t[i]
+	return []interface{}{ind.IndexId, ind.IndexName, ind.From, ind.Till}
+}
+
+// Securities returns Table of moexiss.Security items
+func Securities(items []moexiss.Security) Table {
+	return securityTable(items)
+}
+
+type securityTable []moexiss.Security
+
+var securityColumns = []string{
+	"id",
+	"secid",
+	"shortname",
+	"regnumber",
+	"name",
+	"isin",
+	"is_traded",
+	"emitent_id",
+	"emitent_title",
+	"emitent_inn",
+	"emitent_okpo",
+	"gosreg",
+	"type",
+	"group",
+	"primary_boardid",
+	"marketprice_boardid",
+}
+
+func (t securityTable) Columns() []string { return securityColumns }
+func (t securityTable) Len() int          { return len(t) }
+func (t securityTable) Row(i int) []interface{} {
+	s := t[i]
+	return []interface{}{
+		s.Id,
+		s.SecId,
+		s.ShortName,
+		s.RegNumber,
+		s.Name,
+		s.Isin,
+		boolToInt(s.IsTraded),
+		s.EmitentId,
+		s.EmitentTitle,
+		s.EmitentInn,
+		s.EmitentOkpo,
+		s.GosReg,
+		s.Type,
+		s.Group,
+		s.PrimaryBoardId,
+		s.MarketPriceBoardId,
+	}
+}
+
+// Engines returns Table of moexiss.Engine items of moexiss.Index
+func Engines(items []moexiss.Engine) Table {
+	return engineTable(items)
+}
+
+type engineTable []moexiss.Engine
+
+var engineColumns = []string{"id", "name", "title"}
+
+func (t engineTable) Columns() []string { return engineColumns }
+func (t engineTable) Len() int          { return len(t) }
+func (t engineTable) Row(i int) []interface{} {
+	e := t[i]
+	return []interface{}{e.Id, e.Name, e.Title}
+}
+
+// Markets returns Table of moexiss.Market items of moexiss.Index
+func Markets(items []moexiss.Market) Table {
+	return marketTable(items)
+}
+
+type marketTable []moexiss.Market
+
+var marketColumns = []string{
+	"id",
+	"trade_engine_id",
+	"trade_engine_name",
+	"trade_engine_title",
+	"market_name",
+	"market_title",
+	"marketplace",
+}
+
+func (t marketTable) Columns() []string { return marketColumns }
+func (t marketTable) Len() int          { return len(t) }
+func (t marketTable) Row(i int) []interface{} {
+	m := t[i]
+	return []interface{}{m.Id, m.Engine.Id, m.Engine.Name, m.Engine.Title, m.Name, m.Title, m.MarketPlace}
+}
+
+// Boards returns Table of moexiss.Board items of moexiss.Index
+func Boards(items []moexiss.Board) Table {
+	return boardTable(items)
+}
+
+type boardTable []moexiss.Board
+
+var boardColumns = []string{
+	"id",
+	"board_group_id",
+	"engine_id",
+	"market_id",
+	"boardid",
+	"board_title",
+	"is_traded",
+	"has_candles",
+	"is_primary",
+}
+
+func (t boardTable) Columns() []string { return boardColumns }
+func (t boardTable) Len() int          { return len(t) }
+func (t boardTable) Row(i int) []interface{} {
+	b := t[i]
+	return []interface{}{
+		b.Id,
+		b.BoardGroupId,
+		b.EngineId,
+		b.MarketId,
+		b.BoardId,
+		b.BoardTitle,
+		boolToInt(b.IsTraded),
+		boolToInt(b.HasCandles),
+		boolToInt(b.IsPrimary),
+	}
+}
+
+// BoardGroups returns Table of moexiss.BoardGroup items of moexiss.Index
+func BoardGroups(items []moexiss.BoardGroup) Table {
+	return boardGroupTable(items)
+}
+
+type boardGroupTable []moexiss.BoardGroup
+
+var boardGroupColumns = []string{
+	"id",
+	"trade_engine_id",
+	"trade_engine_name",
+	"trade_engine_title",
+	"market_id",
+	"market_name",
+	"name",
+	"title",
+	"is_default",
+	"is_traded",
+}
+
+func (t boardGroupTable) Columns() []string { return boardGroupColumns }
+func (t boardGroupTable) Len() int          { return len(t) }
+func (t boardGroupTable) Row(i int) []interface{} {
+	bg := t[i]
+	return []interface{}{
+		bg.Id,
+		bg.Engine.Id,
+		bg.Engine.Name,
+		bg.Engine.Title,
+		bg.MarketId,
+		bg.MarketName,
+		bg.Name,
+		bg.Title,
+		boolToInt(bg.IsDefault),
+		boolToInt(bg.IsTraded),
+	}
+}
+
+// Durations returns Table of moexiss.Duration items of moexiss.Index
+func Durations(items []moexiss.Duration) Table {
+	return durationTable(items)
+}
+
+type durationTable []moexiss.Duration
+
+var durationColumns = []string{"interval", "duration", "title", "hint"}
+
+func (t durationTable) Columns() []string { return durationColumns }
+func (t durationTable) Len() int          { return len(t) }
+func (t durationTable) Row(i int) []interface{} {
+	d := t[i]
+	return []interface{}{d.Interval, d.Duration, d.Title, d.Hint}
+}
+
+// SecurityTypes returns Table of moexiss.SecurityType items of moexiss.Index
+func SecurityTypes(items []moexiss.SecurityType) Table {
+	return securityTypeTable(items)
+}
+
+type securityTypeTable []moexiss.SecurityType
+
+var securityTypeColumns = []string{
+	"id",
+	"trade_engine_id",
+	"trade_engine_name",
+	"trade_engine_title",
+	"security_type_name",
+	"security_type_title",
+	"security_group_name",
+}
+
+func (t securityTypeTable) Columns() []string { return securityTypeColumns }
+func (t securityTypeTable) Len() int          { return len(t) }
+func (t securityTypeTable) Row(i int) []interface{} {
+	st := t[i]
+	return []interface{}{st.Id, st.Engine.Id, st.Engine.Name, st.Engine.Title, st.Name, st.Title, st.SecurityGroupName}
+}
+
+// SecurityGroups returns Table of moexiss.SecurityGroup items of moexiss.Index
+func SecurityGroups(items []moexiss.SecurityGroup) Table {
+	return securityGroupTable(items)
+}
+
+type securityGroupTable []moexiss.SecurityGroup
+
+var securityGroupColumns = []string{"id", "name", "title", "is_hidden"}
+
+func (t securityGroupTable) Columns() []string { return securityGroupColumns }
+func (t securityGroupTable) Len() int          { return len(t) }
+func (t securityGroupTable) Row(i int) []interface{} {
+	sg := t[i]
+	return []interface{}{sg.Id, sg.Name, sg.Title, boolToInt(sg.IsHidden)}
+}
+
+// SecurityCollections returns Table of moexiss.SecurityCollection items of moexiss.Index
+func SecurityCollections(items []moexiss.SecurityCollection) Table {
+	return securityCollectionTable(items)
+}
+
+type securityCollectionTable []moexiss.SecurityCollection
+
+var securityCollectionColumns = []string{"id", "name", "title", "security_group_id"}
+
+func (t securityCollectionTable) Columns() []string { return securityCollectionColumns }
+func (t securityCollectionTable) Len() int          { return len(t) }
+func (t securityCollectionTable) Row(i int) []interface{} {
+	sc := t[i]
+	return []interface{}{sc.Id, sc.Name, sc.Title, sc.SecurityGroupId}
+}
+
+// boolToInt converts bool values the same way as MoEx ISS API does
+func boolToInt(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// FormatValue returns a string representation of a value of a Table cell
+// as it is written into CSV, values of other types are formatted by fmt.Sprint
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case interface{ String() string }:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"github.com/dimakoz/moexiss"
+	"testing"
+	"time"
+)
+
+func TestTablesColumnsMatchRows(t *testing.T) {
+	index := moexiss.Index{
+		Engines:             []moexiss.Engine{{}},
+		Markets:             []moexiss.Market{{}},
+		Boards:              []moexiss.Board{{}},
+		BoardGroups:         []moexiss.BoardGroup{{}},
+		Durations:           []moexiss.Duration{{}},
+		SecurityTypes:       []moexiss.SecurityType{{}},
+		SecurityGroups:      []moexiss.SecurityGroup{{}},
+		SecurityCollections: []moexiss.SecurityCollection{{}},
+	}
+	tables := []Table{
+		SecStats([]moexiss.SecStat{{}}),
+		Listings([]moexiss.Listing{{}}),
+		Aggregates([]moexiss.Aggregate{{}}),
+		Turnovers([]moexiss.Turnover{{}}),
+		Indices([]moexiss.Indices{{}}),
+		Securities([]moexiss.Security{{}}),
+		Engines(index.Engines),
+		Markets(index.Markets),
+		Boards(index.Boards),
+		BoardGroups(index.BoardGroups),
+		Durations(index.Durations),
+		SecurityTypes(index.SecurityTypes),
+		SecurityGroups(index.SecurityGroups),
+		SecurityCollections(index.SecurityCollections),
+	}
+	for i, table := range tables {
+		if got, expected := table.Len(), 1; got != expected {
+			t.Fatalf("Error: expecting: \n %v rows\ngot:\n %v rows\ninstead in %d case", expected, got, i)
+		}
+		if got, expected := len(table.Row(0)), len(table.Columns()); got != expected {
+			t.Fatalf("Error: expecting: \n %v values\ngot:\n %v values\ninstead in %d case", expected, got, i)
+		}
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	type Case struct {
+		income   interface{}
+		expected string
+	}
+	cases := []Case{
+		{nil, ""},
+		{"GAZP", "GAZP"},
+		{int64(107517), "107517"},
+		{260.29, "260.29"},
+		{float64(12677905337), "12677905337"},
+		{true, "true"},
+		{moexiss.TradingSessionMain, "1"},
+		{42, "42"},
+		{uint64(18446744073709551615), "18446744073709551615"},
+		{int32(-7), "-7"},
+		{time.Minute, "1m0s"},
+		{[]string{"TQBR", "SMAL"}, "[TQBR SMAL]"},
+	}
+	for i, c := range cases {
+		if got, expected := FormatValue(c.income), c.expected; got != expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
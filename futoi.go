@@ -0,0 +1,307 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/buger/jsonparser"
+	"path"
+	"strings"
+	"time"
+)
+
+// FutOIClientGroup represents a type of participants of the derivatives market
+type FutOIClientGroup string
+
+// A section of FutOIClientGroup values
+const (
+	FutOIClientGroupUndefined     FutOIClientGroup = ""
+	FutOIClientGroupIndividuals   FutOIClientGroup = "FIZ"
+	FutOIClientGroupLegalEntities FutOIClientGroup = "YUR"
+)
+
+// String representations of FutOIClientGroup values
+func (g FutOIClientGroup) String() string {
+	return string(g)
+}
+
+// FutOI struct represents open positions of a group of participants
+// of the derivatives market for the asset
+type FutOI struct {
+	SessionId   int64            // "sess_id"
+	SeqNum      int64            // "seqnum"
+	Time        time.Time        // "tradedate" and "tradetime" in MoscowLocation
+	Ticker      string           // "ticker" Asset code
+	ClientGroup FutOIClientGroup // "clgroup"
+	Position    int64            // "pos" Net position, contracts
+	Long        int64            // "pos_long" Long positions, contracts
+	Short       int64            // "pos_short" Short positions(a negative value), contracts
+	LongNum     int64            // "pos_long_num" Number of participants with long positions
+	ShortNum    int64            // "pos_short_num" Number of participants with short positions
+	SysTime     time.Time        // "systime" Time of publishing in MoscowLocation
+}
+
+// FutOIPoint struct represents open positions of individuals and legal entities
+// for the same trade time
+type FutOIPoint struct {
+	Time          time.Time
+	Individuals   FutOI
+	LegalEntities FutOI
+}
+
+// FutOIResponse struct represents a response with open positions of the derivatives market
+type FutOIResponse struct {
+	Asset string // it's empty for all the assets
+	Items []FutOI
+}
+
+const (
+	futOIAnalyticalPartOfPath = "analyticalproducts"
+	futOIPartOfPath           = "futoi"
+	futOISecuritiesPartOfPath = "securities"
+
+	futOIAllEndpoint   = "analyticalproducts/futoi/securities"
+	futOIAssetEndpoint = "analyticalproducts/futoi/securities/{asset}"
+
+	futOIKeySessionId = "sess_id"
+	futOIKeySeqNum    = "seqnum"
+	futOIKeyTradeDate = "tradedate"
+	futOIKeyTradeTime = "tradetime"
+	futOIKeyTicker    = "ticker"
+	futOIKeyClGroup   = "clgroup"
+	futOIKeyPos       = "pos"
+	futOIKeyPosLong   = "pos_long"
+	futOIKeyPosShort  = "pos_short"
+	futOIKeyLongNum   = "pos_long_num"
+	futOIKeyShortNum  = "pos_short_num"
+	futOIKeySysTime   = "systime"
+	futOIKeyFutOI     = "futoi"
+)
+
+// FutOIService gets open positions of individuals and legal entities
+// on the derivatives market(FUTOI) from the MoEx ISS API.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/analyticalproducts/futoi/securities
+// https://iss.moex.com/iss/analyticalproducts/futoi/securities/[asset]
+type FutOIService service
+
+// GetFutOI provides open positions on the asset(e.g. "si", "ri")
+func (f *FutOIService) GetFutOI(ctx context.Context, asset string, opt *FutOIRequestOptions) (*FutOIResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if asset == "" {
+		return nil, ErrBadSecurityParameter
+	}
+	return f.get(ctx, futOIAssetEndpoint, f.getUrl(asset, opt), asset)
+}
+
+// GetFutOIAll provides open positions on all the assets
+func (f *FutOIService) GetFutOIAll(ctx context.Context, opt *FutOIRequestOptions) (*FutOIResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return f.get(ctx, futOIAllEndpoint, f.getUrl("", opt), "")
+}
+
+func (f *FutOIService) get(ctx context.Context, endpoint string, url string, asset string) (*FutOIResponse, error) {
+	req, err := f.client.newEndpointRequest(endpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = f.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	fr := FutOIResponse{}
+	err = parseFutOIResponse(b.Bytes(), &fr)
+	if err != nil {
+		return nil, err
+	}
+	fr.Asset = asset
+	return &fr, nil
+}
+
+// getUrl provides an url for a request of open positions,
+// an empty 'asset' means all the assets
+// opt *FutOIRequestOptions can be nil, it is safe
+func (f *FutOIService) getUrl(asset string, opt *FutOIRequestOptions) string {
+	url, _ := f.client.BaseURL.Parse(futOIAnalyticalPartOfPath)
+	url.Path = path.Join(url.Path, futOIPartOfPath, futOISecuritiesPartOfPath)
+	if asset != "" {
+		url.Path = path.Join(url.Path, strings.ToLower(asset))
+	}
+	url.Path += ".json"
+	gotURL := addFutOIRequestOptions(url, opt)
+	return gotURL.String()
+}
+
+// Points groups Items by the asset and the trade time,
+// points are in the order of Items
+func (r *FutOIResponse) Points() []FutOIPoint {
+	type pointKey struct {
+		ticker string
+		time   int64
+	}
+	points := make([]FutOIPoint, 0)
+	indexes := make(map[pointKey]int)
+	for _, item := range r.Items {
+		key := pointKey{ticker: item.Ticker, time: item.Time.Unix()}
+		i, ok := indexes[key]
+		if !ok {
+			i = len(points)
+			indexes[key] = i
+			points = append(points, FutOIPoint{Time: item.Time})
+		}
+		switch item.ClientGroup {
+		case FutOIClientGroupIndividuals:
+			points[i].Individuals = item
+		case FutOIClientGroupLegalEntities:
+			points[i].LegalEntities = item
+		}
+	}
+	return points
+}
+
+func parseFutOIResponse(byteData []byte, futOIResponse *FutOIResponse) error {
+	var err error
+	if futOIResponse == nil {
+		err = ErrNilPointer
+		return err
+	}
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(futOIBytes []byte, _ jsonparser.ValueType, offset int, errCb error) {
+		var data []byte
+		var dataType jsonparser.ValueType
+		data, dataType, _, errInCb = jsonparser.Get(futOIBytes, futOIKeyFutOI)
+		if errInCb == nil && data != nil && dataType == jsonparser.Array {
+			errInCb = parseFutOIItems(data, &futOIResponse.Items)
+			if errInCb != nil {
+				return
+			}
+		}
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return err
+}
+
+func parseFutOIItems(byteData []byte, items *[]FutOI) (err error) {
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(futOIItemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.Object {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+
+		item := FutOI{}
+		errInCb = parseFutOI(futOIItemData, &item)
+		if errInCb != nil {
+			return
+		}
+		*items = append(*items, item)
+
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return
+}
+
+func parseFutOI(data []byte, f *FutOI) (err error) {
+	sessionId, err := parseIntWithDefaultValue(data, futOIKeySessionId)
+	if err != nil {
+		return
+	}
+
+	seqNum, err := parseIntWithDefaultValue(data, futOIKeySeqNum)
+	if err != nil {
+		return
+	}
+
+	tradeDate, err := parseStringWithDefaultValueByKey(data, futOIKeyTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	tradeTime, err := parseStringWithDefaultValueByKey(data, futOIKeyTradeTime, "")
+	if err != nil {
+		return
+	}
+
+	ticker, err := parseStringWithDefaultValueByKey(data, futOIKeyTicker, "")
+	if err != nil {
+		return
+	}
+
+	clGroup, err := parseStringWithDefaultValueByKey(data, futOIKeyClGroup, "")
+	if err != nil {
+		return
+	}
+
+	pos, err := parseIntWithDefaultValue(data, futOIKeyPos)
+	if err != nil {
+		return
+	}
+
+	posLong, err := parseIntWithDefaultValue(data, futOIKeyPosLong)
+	if err != nil {
+		return
+	}
+
+	posShort, err := parseIntWithDefaultValue(data, futOIKeyPosShort)
+	if err != nil {
+		return
+	}
+
+	longNum, err := parseIntWithDefaultValue(data, futOIKeyLongNum)
+	if err != nil {
+		return
+	}
+
+	shortNum, err := parseIntWithDefaultValue(data, futOIKeyShortNum)
+	if err != nil {
+		return
+	}
+
+	sysTime, err := parseStringWithDefaultValueByKey(data, futOIKeySysTime, "")
+	if err != nil {
+		return
+	}
+
+	t, err := parseDateTimeWithDefaultValue(tradeDate, tradeTime)
+	if err != nil {
+		return
+	}
+
+	var st time.Time
+	if sysTime != "" {
+		st, err = time.ParseInLocation(issDateTimeLayout, sysTime, MoscowLocation)
+		if err != nil {
+			return
+		}
+	}
+
+	f.SessionId = sessionId
+	f.SeqNum = seqNum
+	f.Time = t
+	f.Ticker = ticker
+	f.ClientGroup = FutOIClientGroup(clGroup)
+	f.Position = pos
+	f.Long = posLong
+	f.Short = posShort
+	f.LongNum = longNum
+	f.ShortNum = shortNum
+	f.SysTime = st
+
+	return
+}
@@ -0,0 +1,99 @@
+package moexiss
+
+import (
+	"net/url"
+	"time"
+)
+
+// FutOIRequestOptions contains options which can be used as arguments
+// for building requests to get open positions of the derivatives market.
+// MoEx ISS API endpoints:
+//
+// https://iss.moex.com/iss/analyticalproducts/futoi/securities
+// https://iss.moex.com/iss/analyticalproducts/futoi/securities/[asset]
+type FutOIRequestOptions struct {
+	from   time.Time // `from` query parameter in url.URL
+	till   time.Time // `till` query parameter in url.URL
+	latest bool      // `latest` query parameter in url.URL
+}
+
+// FutOIReqOptionsBuilder represents a builder of FutOIRequestOptions struct
+type FutOIReqOptionsBuilder struct {
+	options *FutOIRequestOptions
+}
+
+// NewFutOIReqOptionsBuilder is a constructor of FutOIReqOptionsBuilder
+func NewFutOIReqOptionsBuilder() *FutOIReqOptionsBuilder {
+	return &FutOIReqOptionsBuilder{options: &FutOIRequestOptions{}}
+}
+
+// Build builds FutOIRequestOptions from FutOIReqOptionsBuilder
+func (b *FutOIReqOptionsBuilder) Build() *FutOIRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds FutOIRequestOptions from FutOIReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *FutOIReqOptionsBuilder) BuildValidated() (*FutOIRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of FutOIRequestOptions,
+// options can be nil, it is safe
+func (options *FutOIRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return firstError(
+		validateNotFutureDate("from", options.from),
+		validateNotFutureDate("till", options.till),
+		validateDateRange(options.from, options.till),
+	)
+}
+
+// From sets 'from' parameter to a request
+func (b *FutOIReqOptionsBuilder) From(from time.Time) *FutOIReqOptionsBuilder {
+	b.options.from = from
+	return b
+}
+
+// Till sets 'till' parameter to a request
+func (b *FutOIReqOptionsBuilder) Till(till time.Time) *FutOIReqOptionsBuilder {
+	b.options.till = till
+	return b
+}
+
+// Latest sets 'latest' parameter to a request
+// Only the latest open positions are returned if it's true.
+func (b *FutOIReqOptionsBuilder) Latest(latest bool) *FutOIReqOptionsBuilder {
+	b.options.latest = latest
+	return b
+}
+
+// addFutOIRequestOptions sets parameters into *url.URL
+// from FutOIRequestOptions struct and returns it back
+func addFutOIRequestOptions(url *url.URL, options *FutOIRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if !options.from.IsZero() {
+		q.Set("from", options.from.Format("2006-01-02"))
+	}
+	if !options.till.IsZero() {
+		q.Set("till", options.till.Format("2006-01-02"))
+	}
+	if options.latest {
+		q.Set("latest", "1")
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
@@ -0,0 +1,74 @@
+package moexiss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFutOIReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := FutOIRequestOptions{}
+	bld := NewFutOIReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` FutOIRequestOptions \ngot `%v` FutOIRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestNewFutOIRequestOptions(t *testing.T) {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	till := time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)
+	expectStruct := FutOIRequestOptions{from: from, till: till, latest: true}
+	bld := NewFutOIReqOptionsBuilder().From(from).Till(till).Latest(true)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestAddFutOIRequestOptionsNilOptions(t *testing.T) {
+	var income *FutOIRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addFutOIRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddFutOIRequestOptions(t *testing.T) {
+	var incomeOptions = NewFutOIReqOptionsBuilder().
+		From(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)).
+		Till(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)).
+		Latest(true).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addFutOIRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?from=2022-03-01&iss.json=extended&iss.meta=off&latest=1&till=2022-03-04`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestFutOIReqOptionsBuilder_BuildValidated(t *testing.T) {
+	checkBuilderCases(t, []builderCase{
+		{func() (bool, error) {
+			opt, err := NewFutOIReqOptionsBuilder().From(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)).Till(time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC)).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewFutOIReqOptionsBuilder().From(time.Now().AddDate(0, 0, 2)).BuildValidated()
+			return opt != nil, err
+		}, "from"},
+		{func() (bool, error) {
+			opt, err := NewFutOIReqOptionsBuilder().Till(time.Now().AddDate(0, 0, 2)).BuildValidated()
+			return opt != nil, err
+		}, "till"},
+		{func() (bool, error) {
+			opt, err := NewFutOIReqOptionsBuilder().From(time.Date(2023, 12, 2, 0, 0, 0, 0, time.UTC)).Till(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)).BuildValidated()
+			return opt != nil, err
+		}, "till"},
+	})
+}
@@ -0,0 +1,183 @@
+package moexiss
+
+import (
+	"context"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestFutOIGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	if got, expected := c.FutOI.getUrl("Si", nil), `https://iss.moex.com/iss/analyticalproducts/futoi/securities/si.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+	if got, expected := c.FutOI.getUrl("", nil), `https://iss.moex.com/iss/analyticalproducts/futoi/securities.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestFutOIClientGroup_String(t *testing.T) {
+	if got, expected := FutOIClientGroupIndividuals.String(), "FIZ"; got != expected {
+		t.Fatalf("Error: expecting `%s` \ngot `%s` \ninstead", expected, got)
+	}
+	if got, expected := FutOIClientGroupLegalEntities.String(), "YUR"; got != expected {
+		t.Fatalf("Error: expecting `%s` \ngot `%s` \ninstead", expected, got)
+	}
+}
+
+func TestParseFutOI(t *testing.T) {
+	expectedStruct := FutOI{
+		SessionId:   4201,
+		SeqNum:      20220304185001,
+		Time:        time.Date(2022, 3, 4, 18, 45, 0, 0, MoscowLocation),
+		Ticker:      "si",
+		ClientGroup: FutOIClientGroupIndividuals,
+		Position:    289736,
+		Long:        1012485,
+		Short:       -722749,
+		LongNum:     41561,
+		ShortNum:    12690,
+		SysTime:     time.Date(2022, 3, 4, 18, 50, 1, 0, MoscowLocation),
+	}
+	var incomeJSON = `
+{"sess_id": 4201, "seqnum": 20220304185001, "tradedate": "2022-03-04", "tradetime": "18:45:00", "ticker": "si", "clgroup": "FIZ", "pos": 289736, "pos_long": 1012485, "pos_short": -722749, "pos_long_num": 41561, "pos_short_num": 12690, "systime": "2022-03-04 18:50:01"}
+`
+	f := FutOI{}
+	err := parseFutOI([]byte(incomeJSON), &f)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := f, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseFutOIErrCases(t *testing.T) {
+	cases := []string{
+		// no clgroup
+		`{"sess_id": 4201, "seqnum": 1, "tradedate": "2022-03-04", "tradetime": "18:45:00", "ticker": "si", "pos": 1, "pos_long": 1, "pos_short": 0, "pos_long_num": 1, "pos_short_num": 0, "systime": "2022-03-04 18:50:01"}`,
+		// no pos_short_num
+		`{"sess_id": 4201, "seqnum": 1, "tradedate": "2022-03-04", "tradetime": "18:45:00", "ticker": "si", "clgroup": "FIZ", "pos": 1, "pos_long": 1, "pos_short": 0, "pos_long_num": 1, "systime": "2022-03-04 18:50:01"}`,
+		// no systime
+		`{"sess_id": 4201, "seqnum": 1, "tradedate": "2022-03-04", "tradetime": "18:45:00", "ticker": "si", "clgroup": "FIZ", "pos": 1, "pos_long": 1, "pos_short": 0, "pos_long_num": 1, "pos_short_num": 0}`,
+	}
+	for i, c := range cases {
+		f := FutOI{}
+		if got, expected := parseFutOI([]byte(c), &f), jsonparser.KeyPathNotFoundError; got != expected {
+			t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
+
+func TestParseFutOIBadSysTime(t *testing.T) {
+	var incomeJSON = `{"sess_id": 4201, "seqnum": 1, "tradedate": "2022-03-04", "tradetime": "18:45:00", "ticker": "si", "clgroup": "FIZ", "pos": 1, "pos_long": 1, "pos_short": 0, "pos_long_num": 1, "pos_short_num": 0, "systime": "18:50:01"}`
+	f := FutOI{}
+	if err := parseFutOI([]byte(incomeJSON), &f); err == nil {
+		t.Fatalf("Error: expecting an error \ngot <nil> \ninstead")
+	}
+}
+
+func TestParseFutOIItemsUnexpectedDataTypeError(t *testing.T) {
+	items := make([]FutOI, 0)
+	if got, expected := parseFutOIItems([]byte(`[[]]`), &items), ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseFutOIResponseNilError(t *testing.T) {
+	var futOIResponse *FutOIResponse = nil
+	if got, expected := parseFutOIResponse([]byte(``), futOIResponse), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestFutOIResponse_Points(t *testing.T) {
+	byteValue, err := getTestingData("futoi.json")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	fr := FutOIResponse{}
+	if err = parseFutOIResponse(byteValue, &fr); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	points := fr.Points()
+	if got, expected := len(points), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v points\ngot:\n %v points\ninstead", expected, got)
+	}
+	p := points[0]
+	if got, expected := p.Time, time.Date(2022, 3, 4, 18, 45, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := p.Individuals.LongNum, int64(41561); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := p.LegalEntities.Position, int64(-289736); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestFutOIService_GetFutOI(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		byteValue, _ := getTestingData("futoi.json")
+		_, _ = w.Write(byteValue)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.FutOI.GetFutOI(context.Background(), "si", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := gotPath, "/analyticalproducts/futoi/securities/si.json"; got != expected {
+		t.Fatalf("Error: expecting %v path \ngot %v \ninstead", expected, got)
+	}
+	if got, expected := len(result.Items), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := result.Asset, "si"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+
+	if _, err = c.FutOI.GetFutOIAll(context.Background(), nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := gotPath, "/analyticalproducts/futoi/securities.json"; got != expected {
+		t.Fatalf("Error: expecting %v path \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestFutOIService_BadAsset(t *testing.T) {
+	c := NewClient(nil)
+	_, err := c.FutOI.GetFutOI(context.Background(), "", nil)
+	if got, expected := err, ErrBadSecurityParameter; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestFutOIService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	_, err := c.FutOI.GetFutOIAll(context.Background(), nil)
+	if got, expected := err, jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestFutOINilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	_, err := c.FutOI.GetFutOI(ctx, "si", nil)
+	if got, expected := err, ErrNonNilContext; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
@@ -3,6 +3,7 @@ package moexiss
 import (
 	"errors"
 	"github.com/buger/jsonparser"
+	"time"
 	"unicode/utf8"
 )
 
@@ -17,16 +18,32 @@ var (
 	ErrBadBoardParameter      = errors.New("bad 'board' parameter")
 	ErrBadBoardGroupParameter = errors.New("bad 'boardgroup' parameter")
 	ErrEmptyServerResult      = errors.New("the empty answer")
+
+	ErrBadSecurityGroupParameter      = errors.New("bad 'securitygroup' parameter")
+	ErrBadSecurityCollectionParameter = errors.New("bad 'collection' parameter")
+	ErrBadNewsFeedParameter           = errors.New("bad news feed parameter")
+	ErrBadNewsIdParameter             = errors.New("bad news 'id' parameter")
+	ErrBadArchiveParameter            = errors.New("bad archive parameter")
+	ErrBadDateRangeParameter          = errors.New("bad date range parameter")
+	ErrTooManyPages                   = errors.New("too many pages")
+	ErrCursorNotAdvanced              = errors.New("cursor doesn't advance")
 )
 
 const (
-	marketsPartOfPath = "markets"
-	enginePartOfPath  = "engines"
-	historyPartOfPath = "history"
+	marketsPartOfPath    = "markets"
+	enginePartOfPath     = "engines"
+	historyPartOfPath    = "history"
+	statisticsPartOfPath = "statistics"
 
 	nullValue = "null"
+
+	issDateLayout     = "2006-01-02"
+	issDateTimeLayout = "2006-01-02 15:04:05"
 )
 
+// MoscowLocation is the time zone of MoEx ISS API dates and times
+var MoscowLocation = time.FixedZone("MSK", 3*60*60)
+
 func parseStringWithDefaultValue(fieldValue []byte) (string, error) {
 	res, err := jsonparser.ParseString(fieldValue)
 	if err != nil {
@@ -83,6 +100,12 @@ func parseIntWithDefaultValue(fieldValue []byte, key string) (int64, error) {
 	return value, nil
 }
 
+// hasKey reports whether the JSON object contains the key
+func hasKey(data []byte, key string) bool {
+	_, _, _, err := jsonparser.Get(data, key)
+	return err == nil
+}
+
 func isOkSecurityParam(securityId string) bool {
 	if securityId == "" {
 		return false
@@ -90,3 +113,15 @@ func isOkSecurityParam(securityId string) bool {
 	minLen := 3
 	return utf8.RuneCountInString(securityId) >= minLen
 }
+
+// parseDateTimeWithDefaultValue parses a pair of MoEx ISS date and time values
+// in MoscowLocation, an empty date returns zero time.Time, an empty time means the start of the day
+func parseDateTimeWithDefaultValue(date string, clock string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+	if clock == "" {
+		return time.ParseInLocation(issDateLayout, date, MoscowLocation)
+	}
+	return time.ParseInLocation(issDateTimeLayout, date+" "+clock, MoscowLocation)
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestParseStringWithDefaultValueNull(t *testing.T) {
@@ -134,3 +135,31 @@ func emptyHandler(w http.ResponseWriter, _ *http.Request) {
 func getEmptySrv() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(emptyHandler))
 }
+
+func TestParseDateTimeWithDefaultValue(t *testing.T) {
+	got, err := parseDateTimeWithDefaultValue("2022-03-04", "13:30:05")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if expected := time.Date(2022, 3, 4, 10, 30, 5, 0, time.UTC); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+
+	got, err = parseDateTimeWithDefaultValue("2022-03-04", "")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if expected := time.Date(2022, 3, 4, 0, 0, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+
+	got, err = parseDateTimeWithDefaultValue("", "13:30:05")
+	if err != nil || !got.IsZero() {
+		t.Fatalf("Error: expecting zero time and <nil> error: \ngot %v and %v \ninstead", got, err)
+	}
+
+	_, err = parseDateTimeWithDefaultValue("2022-03-04", "25:30")
+	if err == nil {
+		t.Fatalf("Error: expecting an error: \ngot <nil> \ninstead")
+	}
+}
@@ -39,6 +39,10 @@ const (
 	listingKeySecurities = "securities"
 
 	historyListingFilePartsUrl = "listing.json"
+
+	historyListingEndpoint           = "history/engines/{engine}/markets/{market}/listing"
+	historyListingByBoardGrpEndpoint = "history/engines/{engine}/markets/{market}/boardgroups/{boardgroup}/listing"
+	historyListingByBoardEndpoint    = "history/engines/{engine}/markets/{market}/boards/{board}/listing"
 )
 
 // HistoryListingService gets a list of tradable/non-tradable securities
@@ -57,7 +61,7 @@ func (hl *HistoryListingService) GetListing(ctx context.Context, engine EngineNa
 	if err != nil {
 		return nil, err
 	}
-	req, err := hl.client.NewRequest("GET", url, nil)
+	req, err := hl.client.newEndpointRequest(historyListingEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +89,7 @@ func (hl *HistoryListingService) GetListingByBoardGroup(ctx context.Context, eng
 	if err != nil {
 		return nil, err
 	}
-	req, err := hl.client.NewRequest("GET", url, nil)
+	req, err := hl.client.newEndpointRequest(historyListingByBoardGrpEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +118,7 @@ func (hl *HistoryListingService) GetListingByBoard(ctx context.Context, engine E
 	if err != nil {
 		return nil, err
 	}
-	req, err := hl.client.NewRequest("GET", url, nil)
+	req, err := hl.client.newEndpointRequest(historyListingByBoardEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +150,9 @@ func (hl *HistoryListingService) getUrlListing(engine EngineName, market string,
 		return "", ErrBadMarketParameter
 	}
 
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
 	url, _ := hl.client.BaseURL.Parse(historyPartOfPath)
 
 	url.Path = path.Join(url.Path, enginePartOfPath, engine.String(), marketsPartOfPath, market, historyListingFilePartsUrl)
@@ -166,6 +173,9 @@ func (hl *HistoryListingService) getUrlListingByBoard(engine EngineName, market
 	if boardId == "" || utf8.RuneCountInString(boardId) < boardMinLen {
 		return "", ErrBadBoardParameter
 	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
 	url, _ := hl.client.BaseURL.Parse(historyPartOfPath)
 
 	url.Path = path.Join(url.Path, enginePartOfPath, engine.String(), marketsPartOfPath, market, "boards", boardId, historyListingFilePartsUrl)
@@ -186,6 +196,9 @@ func (hl *HistoryListingService) getUrlListingByBoardGroup(engine EngineName, ma
 	if boardGroupId == "" {
 		return "", ErrBadBoardGroupParameter
 	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
 	url, _ := hl.client.BaseURL.Parse(historyPartOfPath)
 
 	url.Path = path.Join(url.Path, enginePartOfPath, engine.String(), marketsPartOfPath, market, "boardgroups", boardGroupId, historyListingFilePartsUrl)
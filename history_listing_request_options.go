@@ -49,6 +49,29 @@ func (b *HistoryListingRequestOptionsBuilder) Build() *HistoryListingRequestOpti
 	return b.options
 }
 
+// BuildValidated builds HistoryListingRequestOptions from HistoryListingRequestOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *HistoryListingRequestOptionsBuilder) BuildValidated() (*HistoryListingRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of HistoryListingRequestOptions,
+// options can be nil, it is safe
+func (options *HistoryListingRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return firstError(
+		validateLang("lang", options.lang),
+		validateOneOf("status", options.status.String(), "unknown trading status",
+			ListingTradingStatusUndefined.String(), ListingTradingStatusAll.String(),
+			ListingTradingStatusNotTraded.String(), ListingTradingStatusTraded.String()),
+	)
+}
+
 // Lang sets 'lang' parameter to a request
 // Language of the result set: 'ru' or 'en'
 // 'ru' by default
@@ -82,3 +82,16 @@ func TestAddHistoryListingRequestOptions(t *testing.T) {
 		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
 	}
 }
+
+func TestHistoryListingRequestOptionsBuilder_BuildValidated(t *testing.T) {
+	checkBuilderCases(t, []builderCase{
+		{func() (bool, error) {
+			opt, err := NewHistoryListingReqOptionsBuilder().Status(ListingTradingStatusTraded).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewHistoryListingReqOptionsBuilder().Status(HistoryListingTradingStatus("halted")).BuildValidated()
+			return opt != nil, err
+		}, "status"},
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 const (
 	indexPartsUrl = "index.json?iss.meta=off"
+	indexEndpoint = "index"
 
 	keyEngines             = "engines"
 	keyMarkets             = "markets"
@@ -20,6 +21,15 @@ const (
 	keySecurityGroups      = "securitygroups"
 	keySecurityCollections = "securitycollections"
 	keyData                = "data"
+	keyColumns             = "columns"
+)
+
+// the columns of the blocks of MoEx ISS API which are parsed by their names,
+// they are used if a block has no columns
+var (
+	marketsColumns     = []string{"id", "trade_engine_id", "trade_engine_name", "trade_engine_title", "market_name", "market_title", "market_id", "marketplace"}
+	boardGroupsColumns = []string{"id", "trade_engine_id", "trade_engine_name", "trade_engine_title", "market_id", "market_name", "name", "title", "is_default", "board_group_id", "is_traded"}
+	durationsColumns   = []string{"interval", "duration", "days", "title", "hint"}
 )
 
 var indexKeys = []string{
@@ -35,9 +45,11 @@ var indexKeys = []string{
 
 //GeneralFields it contains general fields of some other structures
 type GeneralFields struct {
-	Id    int64
-	Name  string
-	Title string
+	Id      int64
+	Name    string
+	Title   string
+	TitleRu string // it's filled by IndexService.ListBilingual only
+	TitleEn string // it's filled by IndexService.ListBilingual only
 }
 
 //Engine represents a description of the trading system
@@ -61,6 +73,8 @@ type Board struct {
 	MarketId     int64
 	BoardId      string
 	BoardTitle   string
+	BoardTitleRu string // it's filled by IndexService.ListBilingual only
+	BoardTitleEn string // it's filled by IndexService.ListBilingual only
 	IsTraded     bool
 	HasCandles   bool
 	IsPrimary    bool
@@ -82,6 +96,10 @@ type Duration struct {
 	Duration int64
 	Title    string
 	Hint     string
+	TitleRu  string // it's filled by IndexService.ListBilingual only
+	TitleEn  string // it's filled by IndexService.ListBilingual only
+	HintRu   string // it's filled by IndexService.ListBilingual only
+	HintEn   string // it's filled by IndexService.ListBilingual only
 }
 
 //SecurityType represent a description of the security type and its attributes
@@ -137,9 +155,12 @@ type IndexService service
 
 //List provides a list of references of MoEx ISS
 func (s *IndexService) List(ctx context.Context, opt *IndexRequestOptions) (*Index, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
 
 	url := s.getUrl(opt)
-	req, err := s.client.NewRequest("GET", url, nil)
+	req, err := s.client.newEndpointRequest(indexEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -269,6 +290,10 @@ func parseEngine(engine *Engine, engineItemBytes []byte) (err error) {
 }
 
 var parseMarkets = func(byteData []byte, index *Index) (err error) {
+	columns, err := parseIndexColumns(byteData, marketsColumns)
+	if err != nil {
+		return
+	}
 	var errInCb error
 	_, err = jsonparser.ArrayEach(byteData, func(marketItemBytes []byte, dataType jsonparser.ValueType, offset int, errCb error) {
 		if errCb != nil {
@@ -276,7 +301,7 @@ var parseMarkets = func(byteData []byte, index *Index) (err error) {
 			return
 		}
 		marketItem := &Market{}
-		errInCb = parseMarket(marketItem, marketItemBytes)
+		errInCb = parseMarket(marketItem, columns, marketItemBytes)
 		if errInCb != nil {
 			return
 		}
@@ -288,7 +313,7 @@ var parseMarkets = func(byteData []byte, index *Index) (err error) {
 	return
 }
 
-func parseMarket(market *Market, marketItemBytes []byte) (err error) {
+func parseMarket(market *Market, columns []string, marketItemBytes []byte) (err error) {
 	var errInCb error
 	counter := 0
 	var cb = func(fieldData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
@@ -297,28 +322,28 @@ func parseMarket(market *Market, marketItemBytes []byte) (err error) {
 			return
 		}
 
-		switch counter {
+		switch columnName(columns, counter) {
 
-		case 0:
+		case "id":
 			market.Id, errInCb = jsonparser.ParseInt(fieldData)
 
-		case 1:
+		case "trade_engine_id":
 			market.Engine.Id, errInCb = jsonparser.ParseInt(fieldData)
 
-		case 2:
+		case "trade_engine_name":
 			market.Engine.Name, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 3:
+		case "trade_engine_title":
 			market.Engine.Title, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 4:
+		case "market_name":
 			market.Name, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 5:
+		case "market_title":
 			market.Title, errInCb = parseStringWithDefaultValue(fieldData)
-		//case 6:
+		//case "market_id":
 		//already presents in market.Id
-		case 7:
+		case "marketplace":
 			market.MarketPlace, errInCb = parseStringWithDefaultValue(fieldData)
 		}
 		if errInCb != nil {
@@ -409,6 +434,10 @@ func parseBoard(board *Board, boardItemBytes []byte) (err error) {
 }
 
 var parseBoardGroups = func(byteData []byte, index *Index) (err error) {
+	columns, err := parseIndexColumns(byteData, boardGroupsColumns)
+	if err != nil {
+		return
+	}
 	var errInCb error
 	_, err = jsonparser.ArrayEach(byteData, func(bgItemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
 		if errCb != nil {
@@ -416,7 +445,7 @@ var parseBoardGroups = func(byteData []byte, index *Index) (err error) {
 			return
 		}
 		boardGroupItem := &BoardGroup{}
-		errInCb = parseBoardGroup(boardGroupItem, bgItemData)
+		errInCb = parseBoardGroup(boardGroupItem, columns, bgItemData)
 		if errInCb != nil {
 			return
 		}
@@ -429,7 +458,7 @@ var parseBoardGroups = func(byteData []byte, index *Index) (err error) {
 
 }
 
-func parseBoardGroup(bg *BoardGroup, boardGroupItemData []byte) (err error) {
+func parseBoardGroup(bg *BoardGroup, columns []string, boardGroupItemData []byte) (err error) {
 	var errInCb error
 	counter := 0
 	var cb = func(fieldData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
@@ -438,37 +467,37 @@ func parseBoardGroup(bg *BoardGroup, boardGroupItemData []byte) (err error) {
 			return
 		}
 
-		switch counter {
+		switch columnName(columns, counter) {
 
-		case 0:
+		case "id":
 			bg.Id, errInCb = jsonparser.ParseInt(fieldData)
 
-		case 1:
+		case "trade_engine_id":
 			bg.Engine.Id, errInCb = jsonparser.ParseInt(fieldData)
 
-		case 2:
+		case "trade_engine_name":
 			bg.Engine.Name, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 3:
+		case "trade_engine_title":
 			bg.Engine.Title, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 4:
+		case "market_id":
 			bg.MarketId, errInCb = jsonparser.ParseInt(fieldData)
 
-		case 5:
+		case "market_name":
 			bg.MarketName, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 6:
+		case "name":
 			bg.Name, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 7:
+		case "title":
 			bg.Title, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 8:
+		case "is_default":
 			bg.IsDefault = string(fieldData) == "1"
 
-		//case 9: Nothing to do
-		case 10:
+		//case "board_group_id": already presents in bg.Id
+		case "is_traded":
 			bg.IsTraded = string(fieldData) == "1"
 
 		}
@@ -487,6 +516,10 @@ func parseBoardGroup(bg *BoardGroup, boardGroupItemData []byte) (err error) {
 }
 
 var parseDurations = func(byteData []byte, index *Index) (err error) {
+	columns, err := parseIndexColumns(byteData, durationsColumns)
+	if err != nil {
+		return
+	}
 	var errInCb error
 	_, err = jsonparser.ArrayEach(byteData, func(durationData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
 		if errCb != nil {
@@ -494,7 +527,7 @@ var parseDurations = func(byteData []byte, index *Index) (err error) {
 			return
 		}
 		durationItem := &Duration{}
-		errInCb = parseDuration(durationItem, durationData)
+		errInCb = parseDuration(durationItem, columns, durationData)
 		if errInCb != nil {
 			return
 		}
@@ -507,7 +540,7 @@ var parseDurations = func(byteData []byte, index *Index) (err error) {
 
 }
 
-func parseDuration(d *Duration, durationData []byte) (err error) {
+func parseDuration(d *Duration, columns []string, durationData []byte) (err error) {
 	var errInCb error
 	counter := 0
 	var cb = func(fieldData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
@@ -516,19 +549,19 @@ func parseDuration(d *Duration, durationData []byte) (err error) {
 			return
 		}
 
-		switch counter {
+		switch columnName(columns, counter) {
 
-		case 0:
+		case "interval":
 			d.Interval, errInCb = jsonparser.ParseInt(fieldData)
 
-		case 1:
+		case "duration":
 			d.Duration, errInCb = jsonparser.ParseInt(fieldData)
 
-		//case 2: Do nothing for 2 value
-		case 3:
+		//case "days": Do nothing for it
+		case "title":
 			d.Title, errInCb = parseStringWithDefaultValue(fieldData)
 
-		case 4:
+		case "hint":
 			d.Hint, errInCb = parseStringWithDefaultValue(fieldData)
 
 		}
@@ -545,6 +578,40 @@ func parseDuration(d *Duration, durationData []byte) (err error) {
 	return
 }
 
+// parseIndexColumns returns the columns of the block of the index or 'defaultColumns' if the block has no columns
+func parseIndexColumns(byteData []byte, defaultColumns []string) ([]string, error) {
+	columns := make([]string, 0, len(defaultColumns))
+	var errInCb error
+	_, err := jsonparser.ArrayEach(byteData, func(value []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.String {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+		columns = append(columns, string(value))
+	}, keyColumns)
+	if err == jsonparser.KeyPathNotFoundError {
+		return defaultColumns, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if errInCb != nil {
+		return nil, errInCb
+	}
+	return columns, nil
+}
+
+// columnName returns the name of the column by its position, it's empty for an unknown column
+func columnName(columns []string, i int) string {
+	if i < len(columns) {
+		return columns[i]
+	}
+	return ""
+}
+
 var parseSecurityTypes = func(byteData []byte, index *Index) (err error) {
 	var errInCb error
 	_, err = jsonparser.ArrayEach(byteData, func(stData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
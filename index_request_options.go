@@ -74,6 +74,51 @@ func (b *IndexReqOptionsBuilder) Build() *IndexRequestOptions {
 	return b.options
 }
 
+// BuildValidated builds IndexRequestOptions from IndexReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *IndexReqOptionsBuilder) BuildValidated() (*IndexRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of IndexRequestOptions,
+// options can be nil, it is safe
+func (options *IndexRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return firstError(
+		validateLang("engines.lang", options.enginesLang),
+		validateLang("markets.lang", options.marketsLang),
+		validateLang("boards.lang", options.boardsLang),
+		validateLang("boardgroups.lang", options.boardGroupsLang),
+		validateLang("durations.lang", options.durationsLang),
+		validateLang("securitytypes.lang", options.securityTypesLang),
+		validateLang("securitygroups.lang", options.securityGroupsLang),
+		validateLang("securitycollections.lang", options.securityCollectionsLang),
+	)
+}
+
+// withLang returns a copy of the options with the language of all the blocks,
+// options can be nil, it is safe
+func (options *IndexRequestOptions) withLang(lang Language) *IndexRequestOptions {
+	opt := IndexRequestOptions{}
+	if options != nil {
+		opt = *options
+	}
+	opt.enginesLang = lang
+	opt.marketsLang = lang
+	opt.boardsLang = lang
+	opt.boardGroupsLang = lang
+	opt.durationsLang = lang
+	opt.securityTypesLang = lang
+	opt.securityGroupsLang = lang
+	opt.securityCollectionsLang = lang
+	return &opt
+}
+
 /* Options of Engine*/
 
 // IndexReqOptionsEngineBuilder facet of IndexReqOptionsBuilder
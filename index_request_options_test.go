@@ -234,3 +234,19 @@ func TestAddIndexRequestOptionsNilOptions(t *testing.T) {
 		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
 	}
 }
+
+func TestIndexReqOptionsBuilder_BuildValidated(t *testing.T) {
+	opt, err := NewIndexReqOptionsBuilder().Engine().Lang(LangEn).SecurityGroup().Lang(LangRu).BuildValidated()
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if opt == nil {
+		t.Fatalf("Error: expecting options \ngot <nil> \ninstead")
+	}
+
+	_, err = NewIndexReqOptionsBuilder().Engine().Lang(LangEn).SecurityCollection().Lang(Language("de")).BuildValidated()
+	ve, ok := err.(*ValidationError)
+	if got, expected := ve, "securitycollections.lang"; !ok || got.Option != expected {
+		t.Fatalf("Error: expecting *ValidationError of `%s` \ngot %v \ninstead", expected, err)
+	}
+}
@@ -393,6 +393,24 @@ func TestIndexParseDurations(t *testing.T) {
 	}
 }
 
+func TestIndexParseDurationsByColumns(t *testing.T) {
+	var incomeJSON = `{
+	"columns": ["interval", "duration", "title", "hint"],
+	"data": [
+		[1, 60, "минута", "1м"]
+	]
+}
+	`
+	var index = newIndex()
+	err := parseDurations([]byte(incomeJSON), index)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v  \ninstead", err)
+	}
+	if got, expected := index.Durations[0], (Duration{Interval: 1, Duration: 60, Title: "минута", Hint: "1м"}); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
 func TestIndexParseDurationsMalformedArrayError(t *testing.T) {
 	var incomeJSON = `{
 	"data": [
@@ -8,7 +8,7 @@ import (
 	"path"
 )
 
-//Indices struct represents a list of the indices that include the security
+// Indices struct represents a list of the indices that include the security
 type Indices struct {
 	IndexId   string // "SECID"
 	IndexName string // "SHORTNAME"
@@ -16,7 +16,7 @@ type Indices struct {
 	Till      string // "TILL"
 }
 
-//IndicesResponse struct represents a response with the list of the indices
+// IndicesResponse struct represents a response with the list of the indices
 type IndicesResponse struct {
 	SecurityId string
 	Indices    []Indices
@@ -24,6 +24,7 @@ type IndicesResponse struct {
 
 const (
 	indicesPartsUrl = "indices.json"
+	indicesEndpoint = "securities/{security}/indices"
 
 	indicesKeyId      = "SECID"
 	indicesKeyName    = "SHORTNAME"
@@ -45,7 +46,7 @@ func (i *IndicesService) GetIndices(ctx context.Context, security string, opt *I
 	if err != nil {
 		return nil, err
 	}
-	req, err := i.client.NewRequest("GET", url, nil)
+	req, err := i.client.newEndpointRequest(indicesEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +67,43 @@ func (i *IndicesService) GetIndices(ctx context.Context, security string, opt *I
 	return &ir, nil
 }
 
+// IndicesBatchItem represents a result of GetIndicesBatch for one security
+type IndicesBatchItem struct {
+	SecurityId string
+	Response   *IndicesResponse
+	Err        error
+}
+
+// IndicesBatchResponse struct represents a response of GetIndicesBatch
+type IndicesBatchResponse struct {
+	Items   []IndicesBatchItem // in the order of the requested securities
+	Summary BatchSummary
+}
+
+// GetIndicesBatch provides lists of the indices that include the securities.
+// Requests are sent concurrently by no more than Client.MaxBatchWorkers workers.
+// A failed request doesn't fail the whole batch, see IndicesBatchItem.Err and Summary.
+func (i *IndicesService) GetIndicesBatch(ctx context.Context, securities []string, opt *IndicesRequestOptions) (*IndicesBatchResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		return nil, ErrNonNilContext
+	}
+	items := make([]IndicesBatchItem, len(securities))
+	errs := make([]error, len(securities))
+	i.client.runBatch(ctx, len(securities), func(ctx context.Context, k int) {
+		items[k].Response, errs[k] = i.GetIndices(ctx, securities[k], opt)
+	}, func(k int, err error) {
+		errs[k] = err
+	})
+	for k := range items {
+		items[k].SecurityId = securities[k]
+		items[k].Err = errs[k]
+	}
+	return &IndicesBatchResponse{Items: items, Summary: summarize(securities, errs)}, nil
+}
+
 // getUrl provides an url for a request of indices with parameters from IndicesRequestOptions
 // opt *IndicesRequestOptions can be nil, it is safe
 // 'security' parameter must not be empty otherwise getUrl returns ErrBadSecurityParameter
@@ -73,6 +111,9 @@ func (i *IndicesService) getUrl(security string, opt *IndicesRequestOptions) (st
 	if !isOkSecurityParam(security) {
 		return "", ErrBadSecurityParameter
 	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
 	url, _ := i.client.BaseURL.Parse("securities")
 
 	url.Path = path.Join(url.Path, security, indicesPartsUrl)
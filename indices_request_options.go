@@ -24,6 +24,24 @@ func (b *IndicesRequestOptionsBuilder) Build() *IndicesRequestOptions {
 	return b.options
 }
 
+// BuildValidated builds IndicesRequestOptions from IndicesRequestOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *IndicesRequestOptionsBuilder) BuildValidated() (*IndicesRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of IndicesRequestOptions,
+// options can be nil, it is safe
+func (options *IndicesRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
 // Lang sets 'lang' parameter to a request
 func (b *IndicesRequestOptionsBuilder) Lang(lang Language) *IndicesRequestOptionsBuilder {
 	b.options.lang = lang
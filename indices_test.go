@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -276,3 +277,57 @@ func TestIndicesService_Indices(t *testing.T) {
 		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
 	}
 }
+
+func TestIndicesService_GetIndicesBatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/bad/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		byteValueResult, err := getTestingData("indices.json")
+		if err != nil {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(byteValueResult)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	c.MaxBatchWorkers = 2
+	securities := []string{"sberp", "bad", "gazp", "sb"}
+	result, err := c.Indices.GetIndicesBatch(context.Background(), securities, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Items), len(securities); got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	for i, item := range result.Items {
+		if got, expected := item.SecurityId, securities[i]; got != expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+		}
+	}
+	if result.Items[0].Err != nil || len(result.Items[0].Response.Indices) == 0 {
+		t.Fatalf("Error: expecting a successful result \ngot %v \ninstead", result.Items[0].Err)
+	}
+	if got, expected := result.Items[3].Err, ErrBadSecurityParameter; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+	if got, expected := result.Summary.Failed, 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v failed\ngot:\n %v failed\ninstead", expected, got)
+	}
+	if !result.Summary.IsPartial() {
+		t.Fatalf("Error: expecting a partial success")
+	}
+}
+
+func TestIndicesService_GetIndicesBatchNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	_, err := c.Indices.GetIndicesBatch(ctx, []string{"sberp"}, nil)
+	if got, expected := err, ErrNonNilContext; got == nil || got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,202 @@
+package moexiss
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointOther is the endpoint of requests which aren't sent by the services, e.g. requests sent by Client.Do
+const EndpointOther = "other"
+
+// endpointKey is the key of the template of the endpoint in the context of a request
+type endpointKey struct{}
+
+// Call represents a request to MoEx ISS API which is passed through the middlewares
+type Call struct {
+	Request  *http.Request
+	Endpoint string // the template of the endpoint, e.g. "engines/{engine}/markets/{market}/secstats"
+}
+
+// RoundTrip sends the request of the call and returns the response as is,
+// the status of the response isn't checked yet
+type RoundTrip func(ctx context.Context, call *Call) (*http.Response, error)
+
+// Middleware wraps a RoundTrip, e.g. to log or to measure requests
+type Middleware func(next RoundTrip) RoundTrip
+
+// Use adds middlewares to the client, the first added middleware is the outermost one.
+// Add middlewares before sending requests, Use isn't safe for concurrent use with requests
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// roundTrip returns the chain of the middlewares which ends with the request by http.Client
+func (c *Client) roundTrip() RoundTrip {
+	rt := RoundTrip(func(ctx context.Context, call *Call) (*http.Response, error) {
+		return c.client.Do(call.Request.WithContext(ctx))
+	})
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+	return rt
+}
+
+// newEndpointRequest creates a GET request of the endpoint of MoEx ISS API,
+// the template of the endpoint relative to Client.BaseURL, e.g. "engines/{engine}/markets/{market}/secstats",
+// is passed to the middlewares by Call.Endpoint
+func (c *Client) newEndpointRequest(endpoint string, urlStr string) (*http.Request, error) {
+	req, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return req.WithContext(context.WithValue(req.Context(), endpointKey{}, endpoint)), nil
+}
+
+// endpointOf returns the template of the endpoint of the request or EndpointOther
+func endpointOf(req *http.Request) string {
+	if endpoint, ok := req.Context().Value(endpointKey{}).(string); ok {
+		return endpoint
+	}
+	return EndpointOther
+}
+
+// statusOf returns the status code of the response or 0 if there is no response
+func statusOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// Logger is a structured logger, keysAndValues are pairs of a key and its value
+type Logger interface {
+	Log(msg string, keysAndValues ...interface{})
+}
+
+type stdLogger struct {
+	logger *log.Logger
+}
+
+// NewStdLogger returns Logger which writes "msg key=value ..." lines to the logger,
+// the standard logger is used if the logger is nil
+func NewStdLogger(logger *log.Logger) Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &stdLogger{logger: logger}
+}
+
+// Log writes the message with the pairs of keys and values
+func (l *stdLogger) Log(msg string, keysAndValues ...interface{}) {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+	l.logger.Print(b.String())
+}
+
+// LoggingMiddleware logs every request with its method, endpoint, url, status, duration and error
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, call)
+			keysAndValues := []interface{}{
+				"method", call.Request.Method,
+				"endpoint", call.Endpoint,
+				"url", call.Request.URL.String(),
+				"status", statusOf(resp),
+				"duration", time.Since(start),
+			}
+			if err != nil {
+				keysAndValues = append(keysAndValues, "error", err)
+			}
+			logger.Log("moexiss request", keysAndValues...)
+			return resp, err
+		}
+	}
+}
+
+// Metrics collects counters and histograms of requests by the templates of endpoints
+type Metrics interface {
+	// CountRequest counts a request, the status is 0 if there is no response
+	CountRequest(endpoint string, status int)
+	// ObserveLatency observes the time till the headers of the response
+	ObserveLatency(endpoint string, latency time.Duration)
+	// ObserveResponseBytes observes the size of the body of the response when the body is closed
+	ObserveResponseBytes(endpoint string, bytes int64)
+}
+
+// MetricsMiddleware reports every request to the metrics
+func MetricsMiddleware(metrics Metrics) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, call)
+			metrics.ObserveLatency(call.Endpoint, time.Since(start))
+			metrics.CountRequest(call.Endpoint, statusOf(resp))
+			if resp != nil && resp.Body != nil {
+				resp.Body = &countingBody{ReadCloser: resp.Body, onClose: func(n int64) {
+					metrics.ObserveResponseBytes(call.Endpoint, n)
+				}}
+			}
+			return resp, err
+		}
+	}
+}
+
+// countingBody counts bytes read from the body and reports them once when the body is closed
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	once    sync.Once
+	onClose func(n int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(b.n) })
+	return err
+}
+
+// Span represents a trace span of a request
+type Span interface {
+	// End ends the span, the status is 0 if there is no response
+	End(status int, err error)
+}
+
+// Tracer starts spans of requests and propagates them to MoEx ISS API, e.g. by an adapter of OpenTelemetry
+type Tracer interface {
+	// StartSpan starts a span of the request and returns the context with the span
+	StartSpan(ctx context.Context, endpoint string, req *http.Request) (context.Context, Span)
+	// Inject sets headers of the span of the context, e.g. "traceparent"
+	Inject(ctx context.Context, header http.Header)
+}
+
+// TracingMiddleware starts a span for every request, the span is named by the template of the endpoint
+// and its context is injected into the headers of the request
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*http.Response, error) {
+			ctx, span := tracer.StartSpan(ctx, call.Endpoint, call.Request)
+			req := call.Request.Clone(ctx)
+			tracer.Inject(ctx, req.Header)
+			resp, err := next(ctx, &Call{Request: req, Endpoint: call.Endpoint})
+			span.End(statusOf(resp), err)
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package moexiss
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testingMetrics struct {
+	mu       sync.Mutex
+	requests map[string]int
+	statuses []int
+	bytes    map[string]int64
+}
+
+func newTestingMetrics() *testingMetrics {
+	return &testingMetrics{requests: make(map[string]int), bytes: make(map[string]int64)}
+}
+
+func (m *testingMetrics) CountRequest(endpoint string, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[endpoint]++
+	m.statuses = append(m.statuses, status)
+}
+
+func (m *testingMetrics) ObserveLatency(string, time.Duration) {}
+
+func (m *testingMetrics) ObserveResponseBytes(endpoint string, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytes[endpoint] += bytes
+}
+
+type testingLogger struct {
+	lines []string
+}
+
+func (l *testingLogger) Log(msg string, keysAndValues ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintln(append([]interface{}{msg}, keysAndValues...)...))
+}
+
+type testingTraceKey struct{}
+
+type testingSpan struct {
+	status *int
+}
+
+func (s testingSpan) End(status int, _ error) {
+	*s.status = status
+}
+
+type testingTracer struct {
+	endpoint string
+	status   int
+}
+
+func (t *testingTracer) StartSpan(ctx context.Context, endpoint string, _ *http.Request) (context.Context, Span) {
+	t.endpoint = endpoint
+	return context.WithValue(ctx, testingTraceKey{}, "00-trace-span-01"), testingSpan{status: &t.status}
+}
+
+func (t *testingTracer) Inject(ctx context.Context, header http.Header) {
+	header.Set("traceparent", ctx.Value(testingTraceKey{}).(string))
+}
+
+func getMiddlewareTestingSrv(status int, fileName string) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Traceparent", r.Header.Get("traceparent"))
+		w.WriteHeader(status)
+		if fileName != "" {
+			b, _ := getTestingData(fileName)
+			_, _ = w.Write(b)
+		}
+	}))
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/iss/")
+	return c, srv.Close
+}
+
+func TestClient_EndpointOf(t *testing.T) {
+	c := NewClient(nil)
+	req, err := c.NewRequest("GET", "index.json", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got := endpointOf(req); got != EndpointOther {
+		t.Fatalf("Error: expecting endpoint %s \ngot %s \ninstead", EndpointOther, got)
+	}
+	req, err = c.newEndpointRequest(indexEndpoint, "index.json")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got := endpointOf(req); got != indexEndpoint {
+		t.Fatalf("Error: expecting endpoint %s \ngot %s \ninstead", indexEndpoint, got)
+	}
+}
+
+func TestArchivesService_DownloadEndpoint(t *testing.T) {
+	c, closeSrv := getMiddlewareTestingSrv(http.StatusOK, "")
+	defer closeSrv()
+	metrics := newTestingMetrics()
+	c.Use(MetricsMiddleware(metrics))
+
+	dst := filepath.Join(t.TempDir(), "2021.zip")
+	fileUrl := c.BaseURL.String() + "downloads/engines/stock/markets/shares/securities/years/2021.zip"
+	if err := c.Archives.Download(context.Background(), ArchiveFile{Url: fileUrl}, dst); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got := metrics.requests[archiveFileEndpoint]; got != 1 {
+		t.Fatalf("Error: expecting 1 request of %s \ngot %d \ninstead", archiveFileEndpoint, got)
+	}
+}
+
+func TestClient_UseOrder(t *testing.T) {
+	c, closeSrv := getMiddlewareTestingSrv(http.StatusOK, "turnover.json")
+	defer closeSrv()
+
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, call *Call) (*http.Response, error) {
+				order = append(order, name+" "+call.Endpoint)
+				return next(ctx, call)
+			}
+		}
+	}
+	c.Use(mark("first"), mark("second"))
+	if _, err := c.Turnovers.GetTurnovers(context.Background(), nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := strings.Join(order, ","), "first turnovers,second turnovers"; got != expected {
+		t.Fatalf("Error: expecting the order %s \ngot %s \ninstead", expected, got)
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	c, closeSrv := getMiddlewareTestingSrv(http.StatusOK, "secstats.json")
+	defer closeSrv()
+	metrics := newTestingMetrics()
+	c.Use(MetricsMiddleware(metrics))
+
+	if _, err := c.Stats.GetSecStats(context.Background(), EngineStock, "shares", nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	b, _ := getTestingData("secstats.json")
+	endpoint := "engines/{engine}/markets/{market}/secstats"
+	if got := metrics.requests[endpoint]; got != 1 {
+		t.Fatalf("Error: expecting 1 request of %s \ngot %d \ninstead", endpoint, got)
+	}
+	if got, expected := metrics.bytes[endpoint], int64(len(b)); got != expected {
+		t.Fatalf("Error: expecting %d bytes \ngot %d \ninstead", expected, got)
+	}
+	if got := metrics.statuses[0]; got != http.StatusOK {
+		t.Fatalf("Error: expecting status %d \ngot %d \ninstead", http.StatusOK, got)
+	}
+}
+
+func TestMetricsMiddlewareErrorStatus(t *testing.T) {
+	c, closeSrv := getMiddlewareTestingSrv(http.StatusInternalServerError, "")
+	defer closeSrv()
+	metrics := newTestingMetrics()
+	c.Use(MetricsMiddleware(metrics))
+
+	if _, err := c.Turnovers.GetTurnovers(context.Background(), nil); err == nil {
+		t.Fatalf("Error: expecting an error of the status")
+	}
+	if got := metrics.statuses; len(got) != 1 || got[0] != http.StatusInternalServerError {
+		t.Fatalf("Error: expecting status %d \ngot %v \ninstead", http.StatusInternalServerError, got)
+	}
+	if _, ok := metrics.bytes["turnovers"]; !ok {
+		t.Fatalf("Error: expecting the bytes of the closed body")
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	c, closeSrv := getMiddlewareTestingSrv(http.StatusOK, "turnover.json")
+	defer closeSrv()
+	logger := &testingLogger{}
+	c.Use(LoggingMiddleware(logger))
+
+	if _, err := c.Turnovers.GetTurnovers(context.Background(), nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "endpoint turnovers") || !strings.Contains(logger.lines[0], "status 200") {
+		t.Fatalf("Error: expecting a log line of the request \ngot %v \ninstead", logger.lines)
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	var b bytes.Buffer
+	NewStdLogger(log.New(&b, "", 0)).Log("moexiss request", "endpoint", "index", "status", 200)
+	if got, expected := b.String(), "moexiss request endpoint=index status=200\n"; got != expected {
+		t.Fatalf("Error: expecting %q \ngot %q \ninstead", expected, got)
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	c, closeSrv := getMiddlewareTestingSrv(http.StatusOK, "turnover.json")
+	defer closeSrv()
+	tracer := &testingTracer{}
+	var traceparent string
+	c.Use(TracingMiddleware(tracer), func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*http.Response, error) {
+			resp, err := next(ctx, call)
+			if resp != nil {
+				traceparent = resp.Header.Get("X-Traceparent")
+			}
+			return resp, err
+		}
+	})
+
+	if _, err := c.Turnovers.GetTurnovers(context.Background(), nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := traceparent, "00-trace-span-01"; got != expected {
+		t.Fatalf("Error: expecting the propagated traceparent %s \ngot %s \ninstead", expected, got)
+	}
+	if tracer.endpoint != "turnovers" || tracer.status != http.StatusOK {
+		t.Fatalf("Error: expecting the span of turnovers with status 200 \ngot %s, %d \ninstead", tracer.endpoint, tracer.status)
+	}
+}
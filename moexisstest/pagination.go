@@ -0,0 +1,71 @@
+package moexisstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const cursorSuffix = ".cursor"
+
+// cursor represents a '<block>.cursor' block of MoEx ISS API
+type cursor struct {
+	Index    int `json:"INDEX"`
+	Total    int `json:"TOTAL"`
+	PageSize int `json:"PAGESIZE"`
+}
+
+// paginate cuts every block of a response in 'extended' JSON format
+// to a page beginning with start and adds a cursor block for it
+func paginate(body []byte, startParam string, pageSize int) ([]byte, error) {
+	start := 0
+	if startParam != "" {
+		var err error
+		start, err = strconv.Atoi(startParam)
+		if err != nil || start < 0 {
+			start = 0
+		}
+	}
+	var sections []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &sections); err != nil {
+		return nil, err
+	}
+	for _, section := range sections {
+		keys := make([]string, 0, len(section))
+		for key := range section {
+			if !strings.HasSuffix(key, cursorSuffix) {
+				keys = append(keys, key)
+			}
+		}
+		for _, key := range keys {
+			value := bytes.TrimSpace(section[key])
+			if len(value) == 0 || value[0] != '[' {
+				continue
+			}
+			var rows []json.RawMessage
+			if err := json.Unmarshal(value, &rows); err != nil {
+				return nil, err
+			}
+			total := len(rows)
+			from, till := start, start+pageSize
+			if from > total {
+				from = total
+			}
+			if till > total {
+				till = total
+			}
+			page, err := json.Marshal(rows[from:till])
+			if err != nil {
+				return nil, err
+			}
+			section[key] = page
+			c, err := json.Marshal([]cursor{{Index: start, Total: total, PageSize: pageSize}})
+			if err != nil {
+				return nil, err
+			}
+			section[key+cursorSuffix] = c
+		}
+	}
+	return json.Marshal(sections)
+}
@@ -0,0 +1,213 @@
+package moexisstest
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RecorderMode represents a mode of Recorder
+type RecorderMode uint8
+
+// A section of RecorderMode values
+const (
+	// ModeReplay serves responses from fixtures without network
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends requests to the network and saves responses as fixtures
+	ModeRecord
+)
+
+// ErrFixtureNotFound is returned by Recorder in ModeReplay if there is no fixture for a request
+var ErrFixtureNotFound = errors.New("fixture not found")
+
+const (
+	// fixtureStatusSuffix is the suffix of the file with the status code of a fixture
+	// of a response which isn't 200 OK
+	fixtureStatusSuffix = ".status"
+	// fixtureNameMaxLen limits the length of a fixture name, the query parameters
+	// of a longer name are replaced with their hash
+	fixtureNameMaxLen = 200
+)
+
+// Recorder is http.RoundTripper which records MoEx ISS responses into fixtures
+// and replays them. Fixtures are plain response bodies stored as JSON files in a directory,
+// like the files of testdata, the status code of a response which isn't 200 OK is stored
+// next to its fixture in a file with ".status" suffix. A fixture is matched by the path
+// and normalized query parameters of a request, so the order of the query parameters doesn't matter.
+type Recorder struct {
+	dir       string
+	mode      RecorderMode
+	transport http.RoundTripper
+
+	mu sync.Mutex
+}
+
+// NewRecorder is a constructor of Recorder
+// transport is used in ModeRecord, http.DefaultTransport is used if it is nil
+func NewRecorder(dir string, mode RecorderMode, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, mode: mode, transport: transport}
+}
+
+// Client returns *http.Client using the Recorder as its transport,
+// it can be passed to moexiss.NewClient
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := filepath.Join(r.dir, FixtureName(req.URL))
+	if r.mode == ModeRecord {
+		return r.record(req, name)
+	}
+	return r.replay(req, name)
+}
+
+func (r *Recorder) replay(req *http.Request, name string) (*http.Response, error) {
+	body, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s for %s", ErrFixtureNotFound, name, req.URL.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	status, err := readFixtureStatus(name + fixtureStatusSuffix)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request, name string) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	clErr := resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if clErr != nil {
+		return nil, clErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err = os.MkdirAll(r.dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(name, body, 0o644); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		err = os.Remove(name + fixtureStatusSuffix)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	} else {
+		err = os.WriteFile(name+fixtureStatusSuffix, []byte(strconv.Itoa(resp.StatusCode)), 0o644)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// readFixtureStatus returns the status code stored in the file, it's 200 OK if there is no file
+func readFixtureStatus(name string) (int, error) {
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return http.StatusOK, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	status, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("bad status code of a fixture %s: %w", name, err)
+	}
+	return status, nil
+}
+
+// FixtureName returns a file name of a fixture for the URL.
+// The name is built from the path and the normalized query parameters,
+// e.g. 'iss_engines_stock_markets_shares_secstats_securities-GAZP.json'.
+// The query parameters of a name longer than fixtureNameMaxLen are replaced with their hash.
+func FixtureName(u *url.URL) string {
+	name := fixtureNamePart(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".json"))
+	if name == "" {
+		name = "root"
+	}
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil || len(values) == 0 {
+		return name + ".json"
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(name)
+	for _, key := range keys {
+		params := append([]string{}, values[key]...)
+		sort.Strings(params)
+		for _, v := range params {
+			b.WriteString("_" + fixtureNamePart(key) + "-" + fixtureNamePart(v))
+		}
+	}
+	if b.Len() > fixtureNameMaxLen {
+		sum := sha1.Sum([]byte(NormalizeQuery(u.RawQuery)))
+		return name + "_" + hex.EncodeToString(sum[:4]) + ".json"
+	}
+	return b.String() + ".json"
+}
+
+// fixtureNamePart replaces the characters which aren't letters, digits, '.' or '-' with '_'
+func fixtureNamePart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// NormalizeQuery returns the query with parameters sorted by keys and values
+func NormalizeQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	for _, v := range values {
+		sort.Strings(v)
+	}
+	return values.Encode()
+}
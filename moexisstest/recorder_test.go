@@ -0,0 +1,104 @@
+package moexisstest
+
+import (
+	"context"
+	"errors"
+	"github.com/dimakoz/moexiss"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	srv := NewServer()
+
+	recorder := NewRecorder(dir, ModeRecord, nil)
+	c := moexiss.NewClient(recorder.Client())
+	c.BaseURL, _ = url.Parse(srv.URL())
+	opt := moexiss.NewStatReqOptionsBuilder().AddTicker("GAZP").TypeTradingSession(moexiss.TradingSessionMain).Build()
+	recorded, err := c.Stats.GetSecStats(context.Background(), moexiss.EngineStock, "shares", opt)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if got, expected := len(files), 1; got != expected {
+		t.Fatalf("Error: expecting: \n %v fixtures\ngot:\n %v fixtures\ninstead", expected, got)
+	}
+
+	replayer := NewRecorder(dir, ModeReplay, nil)
+	c = moexiss.NewClient(replayer.Client())
+	c.BaseURL, _ = url.Parse(srv.URL())
+	replayed, err := c.Stats.GetSecStats(context.Background(), moexiss.EngineStock, "shares", opt)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(replayed.SecStats), len(recorded.SecStats); got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+}
+
+func TestRecorderReplayNotFound(t *testing.T) {
+	c := moexiss.NewClient(NewRecorder(t.TempDir(), ModeReplay, nil).Client())
+	_, err := c.Turnovers.GetTurnovers(context.Background(), nil)
+	if got, expected := err, ErrFixtureNotFound; !errors.Is(got, expected) {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestRecorderRecordsErrors(t *testing.T) {
+	dir := t.TempDir()
+	srv := NewServer()
+	srv.Inject(PatternTurnovers, Fault{StatusCode: http.StatusInternalServerError})
+
+	c := moexiss.NewClient(NewRecorder(dir, ModeRecord, nil).Client())
+	c.BaseURL, _ = url.Parse(srv.URL())
+	_, recorded := c.Turnovers.GetTurnovers(context.Background(), nil)
+	if recorded == nil {
+		t.Fatalf("Error: expecting non-nil error: \ngot <nil> instead")
+	}
+	srv.Close()
+
+	c = moexiss.NewClient(NewRecorder(dir, ModeReplay, nil).Client())
+	c.BaseURL, _ = url.Parse(srv.URL())
+	_, replayed := c.Turnovers.GetTurnovers(context.Background(), nil)
+	if replayed == nil || !strings.Contains(replayed.Error(), "status:[500]") {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", recorded, replayed)
+	}
+}
+
+func TestFixtureName(t *testing.T) {
+	type Case struct {
+		income   string
+		expected string
+	}
+	cases := []Case{
+		{"https://iss.moex.com/iss/index.json", "iss_index.json"},
+		{"https://iss.moex.com/iss/turnovers.json?iss.meta=off&iss.json=extended", "iss_turnovers_iss.json-extended_iss.meta-off.json"},
+		{"https://iss.moex.com/iss/turnovers.json?iss.json=extended&iss.meta=off", "iss_turnovers_iss.json-extended_iss.meta-off.json"},
+		{"https://iss.moex.com/iss/engines/stock/markets/shares/secstats.json?securities=SBER,GAZP&boardid=TQBR",
+			"iss_engines_stock_markets_shares_secstats_boardid-TQBR_securities-SBER_GAZP.json"},
+		{"https://iss.moex.com/iss/engines/stock/markets/shares/secstats.json?securities=" + strings.Repeat("SBER,", 50),
+			"iss_engines_stock_markets_shares_secstats_7ff1ba44.json"},
+		{"http://127.0.0.1/", "root.json"},
+	}
+	for i, c := range cases {
+		u, _ := url.Parse(c.income)
+		if got, expected := FixtureName(u), c.expected; got != expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	if got, expected := NormalizeQuery("securities=SBER&boardid=TQBR&boardid=SMAL"), "boardid=SMAL&boardid=TQBR&securities=SBER"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := NormalizeQuery("%zz"), "%zz"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,66 @@
+package moexisstest
+
+import "strings"
+
+// A section of URL patterns served by Server by default.
+// A pattern is a path relative to the base URL of MoEx ISS API,
+// a part in curly braces matches any single path segment
+// or its non-empty beginning if the part has a suffix.
+const (
+	PatternIndex                = "index.json"
+	PatternTurnovers            = "turnovers.json"
+	PatternAggregates           = "securities/{security}/aggregates.json"
+	PatternIndices              = "securities/{security}/indices.json"
+	PatternSecStats             = "engines/{engine}/markets/{market}/secstats.json"
+	PatternListing              = "history/engines/{engine}/markets/{market}/listing.json"
+	PatternListingByBoard       = "history/engines/{engine}/markets/{market}/boards/{board}/listing.json"
+	PatternListingByBoardGroup  = "history/engines/{engine}/markets/{market}/boardgroups/{boardgroup}/listing.json"
+	PatternFutOI                = "analyticalproducts/futoi/securities/{asset}.json"
+	PatternCollectionSecurities = "securitygroups/{group}/collections/{collection}/securities.json"
+)
+
+// defaultRoutes maps the default patterns to fixture files
+var defaultRoutes = []route{
+	{pattern: PatternIndex, fixture: "index.json"},
+	{pattern: PatternTurnovers, fixture: "turnovers.json"},
+	{pattern: PatternAggregates, fixture: "aggregates.json"},
+	{pattern: PatternIndices, fixture: "indices.json"},
+	{pattern: PatternSecStats, fixture: "secstats.json"},
+	{pattern: PatternListing, fixture: "history_listing.json"},
+	{pattern: PatternListingByBoard, fixture: "history_listing_board.json"},
+	{pattern: PatternListingByBoardGroup, fixture: "history_listing_boardgroups.json"},
+	{pattern: PatternFutOI, fixture: "futoi.json"},
+	{pattern: PatternCollectionSecurities, fixture: "collection_securities.json"},
+}
+
+// route represents a pattern served by a fixture file or by a body
+type route struct {
+	pattern  string
+	fixture  string
+	body     []byte
+	fault    *Fault
+	pageSize int
+}
+
+// matchPattern reports whether the path matches the pattern
+func matchPattern(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if end := strings.Index(part, "}"); strings.HasPrefix(part, "{") && end > 0 {
+			// a placeholder may be followed by a suffix like "{asset}.json"
+			suffix := part[end+1:]
+			if len(pathParts[i]) <= len(suffix) || !strings.HasSuffix(pathParts[i], suffix) {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,223 @@
+// Package moexisstest provides a fake MoEx ISS server for tests.
+//
+// Server routes requests of moexiss services to JSON fixtures,
+// it supports error injection and paginated results:
+//
+//	srv := moexisstest.NewServer()
+//	defer srv.Close()
+//
+//	srv.Inject(moexisstest.PatternTurnovers, moexisstest.Fault{StatusCode: http.StatusBadGateway})
+//	_, err := srv.Client().Turnovers.GetTurnovers(context.Background(), nil)
+package moexisstest
+
+import (
+	"embed"
+	"github.com/dimakoz/moexiss"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var embeddedFixtures embed.FS
+
+// Fixtures returns the default fixtures served by Server
+func Fixtures() fs.FS {
+	fixtures, _ := fs.Sub(embeddedFixtures, "fixtures")
+	return fixtures
+}
+
+// Fault describes an error injected into responses of a pattern
+type Fault struct {
+	StatusCode    int           // a status code of the response, if it isn't 0
+	Latency       time.Duration // a delay before the response
+	MalformedJSON bool          // truncate the body of the response to make it malformed
+	Times         int           // the number of responses affected by the fault, 0 means all of them
+}
+
+// Server represents a fake MoEx ISS server
+type Server struct {
+	srv      *httptest.Server
+	fixtures fs.FS
+
+	mu       sync.Mutex
+	routes   []*route
+	requests []*url.URL
+}
+
+// NewServer starts and returns a new Server with the default fixtures.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	return NewServerWithFixtures(Fixtures())
+}
+
+// NewServerWithFixtures starts and returns a new Server which reads fixtures from fsys.
+// fsys must contain files named as the default fixtures, see Fixtures.
+// The caller should call Close when finished, to shut it down.
+func NewServerWithFixtures(fsys fs.FS) *Server {
+	s := &Server{fixtures: fsys}
+	for _, r := range defaultRoutes {
+		r := r
+		s.routes = append(s.routes, &r)
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the base URL of the server with a trailing slash
+func (s *Server) URL() string {
+	return s.srv.URL + "/"
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns *moexiss.Client pointed at the server
+func (s *Server) Client() *moexiss.Client {
+	c := moexiss.NewClient(s.srv.Client())
+	c.BaseURL, _ = url.Parse(s.URL())
+	return c
+}
+
+// SetFixture sets a fixture file of fsys served by the pattern
+func (s *Server) SetFixture(pattern, fixture string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.findOrAddRoute(pattern)
+	r.fixture = fixture
+	r.body = nil
+}
+
+// SetBody sets a body served by the pattern instead of a fixture file
+func (s *Server) SetBody(pattern string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.findOrAddRoute(pattern)
+	r.fixture = ""
+	r.body = body
+}
+
+// Inject injects the fault into responses of the pattern
+func (s *Server) Inject(pattern string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.findOrAddRoute(pattern).fault = &f
+}
+
+// Reset removes faults of the pattern
+func (s *Server) Reset(pattern string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.findOrAddRoute(pattern).fault = nil
+}
+
+// SetPageSize makes responses of the pattern paginated.
+// Every block is cut to pageSize rows beginning with 'start' query parameter
+// and a '<block>.cursor' block with INDEX, TOTAL and PAGESIZE is added.
+// 0 turns the pagination off.
+func (s *Server) SetPageSize(pattern string, pageSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.findOrAddRoute(pattern).pageSize = pageSize
+}
+
+// Requests returns URLs of all the requests received by the server
+func (s *Server) Requests() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]*url.URL, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+func (s *Server) findOrAddRoute(pattern string) *route {
+	pattern = strings.Trim(pattern, "/")
+	for _, r := range s.routes {
+		if r.pattern == pattern {
+			return r
+		}
+	}
+	r := &route{pattern: pattern}
+	s.routes = append(s.routes, r)
+	return r
+}
+
+// match returns a copy of the route matching the path with a copy of its fault
+// and consumes the fault of the route, the copies aren't shared with other requests
+func (s *Server) match(u *url.URL) (route, Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, u)
+	for _, r := range s.routes {
+		if !matchPattern(r.pattern, u.Path) {
+			continue
+		}
+		matched := *r
+		matched.fault = nil
+		var fault Fault
+		if r.fault != nil {
+			fault = *r.fault
+			if r.fault.Times > 0 {
+				r.fault.Times--
+				if r.fault.Times == 0 {
+					r.fault = nil
+				}
+			}
+		}
+		return matched, fault, true
+	}
+	return route{}, Fault{}, false
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	u := *req.URL
+	r, fault, ok := s.match(&u)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	if fault.Latency > 0 {
+		select {
+		case <-time.After(fault.Latency):
+		case <-req.Context().Done():
+			return
+		}
+	}
+	if fault.StatusCode != 0 && (fault.StatusCode < 200 || fault.StatusCode > 299) {
+		http.Error(w, http.StatusText(fault.StatusCode), fault.StatusCode)
+		return
+	}
+	body, err := s.body(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if r.pageSize > 0 {
+		body, err = paginate(body, req.URL.Query().Get("start"), r.pageSize)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if fault.MalformedJSON {
+		body = body[:len(body)/2]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if fault.StatusCode != 0 {
+		w.WriteHeader(fault.StatusCode)
+	}
+	_, _ = w.Write(body)
+}
+
+func (s *Server) body(r route) ([]byte, error) {
+	if r.body != nil {
+		return r.body, nil
+	}
+	return fs.ReadFile(s.fixtures, r.fixture)
+}
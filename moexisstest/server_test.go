@@ -0,0 +1,258 @@
+package moexisstest
+
+import (
+	"context"
+	"github.com/buger/jsonparser"
+	"github.com/dimakoz/moexiss"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestServerDefaultRoutes(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := srv.Client()
+	ctx := context.Background()
+
+	turnovers, err := c.Turnovers.GetTurnovers(ctx, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(*turnovers), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	index, err := c.Index.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(index.SecurityCollections), 103; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if _, err = c.Aggregates.GetAggregates(ctx, "sberp", nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if _, err = c.Indices.GetIndices(ctx, "sberp", nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if _, err = c.Stats.GetSecStats(ctx, moexiss.EngineStock, "shares", nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if _, err = c.HistoryListing.GetListingByBoard(ctx, moexiss.EngineStock, "shares", "TQTD", nil); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	futOI, err := c.FutOI.GetFutOI(ctx, "si", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(futOI.Points()), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v points\ngot:\n %v points\ninstead", expected, got)
+	}
+	if got, expected := len(srv.Requests()), 7; got != expected {
+		t.Fatalf("Error: expecting: \n %v requests\ngot:\n %v requests\ninstead", expected, got)
+	}
+}
+
+func TestServerNotFound(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, err := srv.Client().Securities.List(context.Background())
+	if got, expected := err, "status:[404] 404 Not Found"; got == nil || got.Error() != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestServerInjectStatusCode(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	srv.Inject(PatternTurnovers, Fault{StatusCode: http.StatusBadGateway, Times: 1})
+	_, err := c.Turnovers.GetTurnovers(context.Background(), nil)
+	if got, expected := err, "status:[502] 502 Bad Gateway"; got == nil || got.Error() != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+	_, err = c.Turnovers.GetTurnovers(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+}
+
+func TestServerInjectTimesConcurrently(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	times, requests := 50, 200
+	srv.Inject(PatternTurnovers, Fault{StatusCode: http.StatusBadGateway, Times: times})
+	var wg sync.WaitGroup
+	var failures int32
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// the handler is called directly to send the requests as parallel as possible
+			rec := httptest.NewRecorder()
+			srv.serveHTTP(rec, httptest.NewRequest(http.MethodGet, "/turnovers.json", nil))
+			if rec.Code == http.StatusBadGateway {
+				atomic.AddInt32(&failures, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := int(atomic.LoadInt32(&failures)); got != times {
+		t.Fatalf("Error: expecting %d failed requests \ngot %d \ninstead", times, got)
+	}
+}
+
+func TestServerInjectMalformedJSON(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Inject(PatternSecStats, Fault{MalformedJSON: true})
+	_, err := srv.Client().Stats.GetSecStats(context.Background(), moexiss.EngineStock, "shares", nil)
+	if err == nil {
+		t.Fatalf("Error: expecting non-nil error: \ngot <nil> instead")
+	}
+	srv.Reset(PatternSecStats)
+	_, err = srv.Client().Stats.GetSecStats(context.Background(), moexiss.EngineStock, "shares", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+}
+
+func TestServerInjectLatency(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	latency := 100 * time.Millisecond
+	srv.Inject(PatternTurnovers, Fault{Latency: latency})
+	started := time.Now()
+	_, err := srv.Client().Turnovers.GetTurnovers(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := time.Since(started), latency; got < expected {
+		t.Fatalf("Error: expecting a response after %v \ngot it after %v \ninstead", expected, got)
+	}
+}
+
+func TestServerSetBody(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.SetBody(PatternTurnovers, []byte(`[{"turnovers": []}]`))
+	result, err := srv.Client().Turnovers.GetTurnovers(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(*result), 0; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	srv.SetFixture(PatternTurnovers, "turnovers.json")
+	result, err = srv.Client().Turnovers.GetTurnovers(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(*result), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	srv.SetPageSize(PatternListing, 10)
+	total := 0
+	for start := uint64(0); ; start += 10 {
+		opt := moexiss.NewHistoryListingReqOptionsBuilder().Start(start).Build()
+		result, err := c.HistoryListing.GetListing(context.Background(), moexiss.EngineStock, "shares", opt)
+		if err == moexiss.ErrEmptyServerResult {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		if len(result.Listing) > 10 {
+			t.Fatalf("Error: expecting no more than 10 items \ngot %v items \ninstead", len(result.Listing))
+		}
+		total += len(result.Listing)
+	}
+	fullSrv := NewServer()
+	defer fullSrv.Close()
+	full, err := fullSrv.Client().HistoryListing.GetListing(context.Background(), moexiss.EngineStock, "shares", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := total, len(full.Listing); got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+}
+
+func TestServerCollectionSecuritiesPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.SetPageSize(PatternCollectionSecurities, 2)
+	result, err := srv.Client().SecurityGroups.CollectionSecuritiesAll(context.Background(), "stock_shares", "stock_shares_one", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Securities), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := len(srv.Requests()), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v requests\ngot:\n %v requests\ninstead", expected, got)
+	}
+}
+
+func TestPaginateCursor(t *testing.T) {
+	body := []byte(`[{"charsetinfo": {"name": "utf-8"}}, {"securities": [{"SECID": "A"}, {"SECID": "B"}, {"SECID": "C"}]}]`)
+	page, err := paginate(body, "2", 2)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	total, err := jsonparser.GetInt(page, "[1]", "securities.cursor", "[0]", "TOTAL")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := total, int64(3); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	secId, err := jsonparser.GetString(page, "[1]", "securities", "[0]", "SECID")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := secId, "C"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	type Case struct {
+		pattern  string
+		path     string
+		expected bool
+	}
+	cases := []Case{
+		{PatternIndex, "/index.json", true},
+		{PatternAggregates, "/securities/sberp/aggregates.json", true},
+		{PatternAggregates, "/securities//aggregates.json", false},
+		{PatternSecStats, "/engines/stock/markets/shares/secstats.json", true},
+		{PatternSecStats, "/engines/stock/markets/secstats.json", false},
+		{PatternListing, "/history/engines/stock/markets/shares/boards/TQBR/listing.json", false},
+		{PatternFutOI, "/analyticalproducts/futoi/securities/si.json", true},
+		{PatternFutOI, "/analyticalproducts/futoi/securities/.json", false},
+		{PatternFutOI, "/analyticalproducts/futoi/securities/si", false},
+	}
+	for i, c := range cases {
+		if got, expected := matchPattern(c.pattern, c.path), c.expected; got != expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
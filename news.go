@@ -0,0 +1,401 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// NewsFeed is a type of the exchange news feeds
+type NewsFeed string
+
+// A list of the exchange news feeds
+const (
+	NewsFeedSite   NewsFeed = "sitenews" // news of the exchange site, trading halts and listing changes are announced there
+	NewsFeedEvents NewsFeed = "events"   // events of the exchange
+)
+
+// String representations of NewsFeed value
+func (nf NewsFeed) String() string {
+	return string(nf)
+}
+
+// NewsItem struct represents an item of the exchange news feed
+type NewsItem struct {
+	Id          int64     // "id"
+	Tag         string    // "tag"
+	Title       string    // "title"
+	PublishedAt time.Time // "published_at" in MoscowLocation
+	ModifiedAt  time.Time // "modified_at" in MoscowLocation, it's zero if the item wasn't modified
+}
+
+// NewsContent struct represents a single item of the exchange news feed with its body
+type NewsContent struct {
+	NewsItem
+	Body string // "body" HTML body of the item
+}
+
+// NewsResponse struct represents a response with a page of the news feed
+type NewsResponse struct {
+	Feed   NewsFeed
+	Items  []NewsItem // the newest items come first
+	Cursor *Cursor    // it's nil if MoEx ISS API doesn't provide it
+}
+
+const (
+	newsFileExtension = ".json"
+	newsKeyContent    = "content"
+
+	newsKeyId          = "id"
+	newsKeyTag         = "tag"
+	newsKeyTitle       = "title"
+	newsKeyBody        = "body"
+	newsKeyPublishedAt = "published_at"
+	newsKeyModifiedAt  = "modified_at"
+
+	// newsItemEndpointSuffix is the suffix of the endpoint of a feed item, e.g. "sitenews/{id}"
+	newsItemEndpointSuffix = "/{id}"
+
+	// newsPollerMaxPages limits the number of pages requested by a poll
+	// if the last seen item is far behind
+	newsPollerMaxPages = 10
+
+	// defaultNewsPollInterval is the interval of NewsPoller.Run by default
+	defaultNewsPollInterval = time.Minute
+)
+
+// NewsService gets the exchange news and events
+// from the MoEx ISS API.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/sitenews
+// https://iss.moex.com/iss/sitenews/[news_id]
+// https://iss.moex.com/iss/events
+// https://iss.moex.com/iss/events/[event_id]
+type NewsService service
+
+// SiteNews provides a page of the exchange site news, see NewsReqOptionsBuilder.Start
+func (n *NewsService) SiteNews(ctx context.Context, opt *NewsRequestOptions) (*NewsResponse, error) {
+	return n.List(ctx, NewsFeedSite, opt)
+}
+
+// SiteNewsItem provides the exchange site news item with its body
+func (n *NewsService) SiteNewsItem(ctx context.Context, id int64, opt *NewsRequestOptions) (*NewsContent, error) {
+	return n.Item(ctx, NewsFeedSite, id, opt)
+}
+
+// Events provides a page of the exchange events, see NewsReqOptionsBuilder.Start
+func (n *NewsService) Events(ctx context.Context, opt *NewsRequestOptions) (*NewsResponse, error) {
+	return n.List(ctx, NewsFeedEvents, opt)
+}
+
+// Event provides the exchange event with its body
+func (n *NewsService) Event(ctx context.Context, id int64, opt *NewsRequestOptions) (*NewsContent, error) {
+	return n.Item(ctx, NewsFeedEvents, id, opt)
+}
+
+// List provides a page of the news feed, see NewsReqOptionsBuilder.Start
+func (n *NewsService) List(ctx context.Context, feed NewsFeed, opt *NewsRequestOptions) (*NewsResponse, error) {
+	url, err := n.getUrl(feed, 0, opt)
+	if err != nil {
+		return nil, err
+	}
+	b, err := n.get(ctx, feed.String(), url)
+	if err != nil {
+		return nil, err
+	}
+	nr := NewsResponse{Feed: feed, Items: make([]NewsItem, 0)}
+	err = parseNewsResponse(b, feed.String(), &nr)
+	if err != nil {
+		return nil, err
+	}
+	return &nr, nil
+}
+
+// Item provides the item of the news feed with its body.
+// It returns ErrEmptyServerResult if there is no such item.
+func (n *NewsService) Item(ctx context.Context, feed NewsFeed, id int64, opt *NewsRequestOptions) (*NewsContent, error) {
+	if id <= 0 {
+		return nil, ErrBadNewsIdParameter
+	}
+	url, err := n.getUrl(feed, id, opt)
+	if err != nil {
+		return nil, err
+	}
+	b, err := n.get(ctx, feed.String()+newsItemEndpointSuffix, url)
+	if err != nil {
+		return nil, err
+	}
+	nr := NewsResponse{Items: make([]NewsItem, 0)}
+	contents := make([]NewsContent, 0)
+	err = parseBlockWithCursor(b, newsKeyContent, &nr.Cursor, func(data []byte) error {
+		nc := NewsContent{}
+		errParse := parseNewsContent(data, &nc)
+		if errParse == nil {
+			contents = append(contents, nc)
+		}
+		return errParse
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return nil, ErrEmptyServerResult
+	}
+	return &contents[0], nil
+}
+
+// NewPoller creates a poller of the news feed which emits only the items
+// published after the item with lastSeenId.
+// Zero lastSeenId means the first poll only remembers the newest item and emits nothing.
+// A non-positive interval is replaced by defaultNewsPollInterval.
+func (n *NewsService) NewPoller(feed NewsFeed, interval time.Duration, lastSeenId int64) *NewsPoller {
+	if interval <= 0 {
+		interval = defaultNewsPollInterval
+	}
+	return &NewsPoller{service: n, feed: feed, interval: interval, lastSeenId: lastSeenId}
+}
+
+func (n *NewsService) get(ctx context.Context, endpoint string, url string) ([]byte, error) {
+	req, err := n.client.newEndpointRequest(endpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = n.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// getUrl provides an url for a request of the news feed
+// or of its item if 'id' is positive
+// opt *NewsRequestOptions can be nil, it is safe
+func (n *NewsService) getUrl(feed NewsFeed, id int64, opt *NewsRequestOptions) (string, error) {
+	if feed != NewsFeedSite && feed != NewsFeedEvents {
+		return "", ErrBadNewsFeedParameter
+	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
+	url, _ := n.client.BaseURL.Parse(feed.String())
+	if id > 0 {
+		url.Path = path.Join(url.Path, strconv.FormatInt(id, 10))
+	}
+	url.Path += newsFileExtension
+	gotURL := addNewsRequestOptions(url, opt)
+	return gotURL.String(), nil
+}
+
+// NewsPoller polls the news feed and emits only new items.
+// It's safe to call LastSeenId concurrently with Poll and Run.
+type NewsPoller struct {
+	service  *NewsService
+	feed     NewsFeed
+	interval time.Duration
+
+	pollMu     sync.Mutex // serializes polls
+	mu         sync.Mutex // guards lastSeenId, it isn't held during requests
+	lastSeenId int64
+
+	// OnError is called by Run for an error of a poll, Run keeps polling after it.
+	// Errors are ignored if it's nil.
+	OnError func(err error)
+}
+
+// LastSeenId returns the id of the newest item seen by the poller,
+// it may be stored to resume polling later
+func (p *NewsPoller) LastSeenId() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastSeenId
+}
+
+// Poll requests the news feed and returns the items published after the last seen one,
+// the oldest item comes first.
+// It requests up to newsPollerMaxPages pages if there are more new items than a page.
+// If the last seen item isn't reached within newsPollerMaxPages pages, it returns the requested items
+// with ErrTooManyPages, the older new items are skipped.
+func (p *NewsPoller) Poll(ctx context.Context) ([]NewsItem, error) {
+	p.pollMu.Lock()
+	defer p.pollMu.Unlock()
+
+	lastSeenId := p.LastSeenId()
+	newItems := make([]NewsItem, 0)
+	truncated := true
+	var start uint64
+	for page := 0; page < newsPollerMaxPages; page++ {
+		opt := NewNewsReqOptionsBuilder().Start(start).Build()
+		nr, err := p.service.List(ctx, p.feed, opt)
+		if err != nil {
+			return nil, err
+		}
+		if lastSeenId == 0 {
+			// the first poll without a last seen item only remembers the newest one
+			if len(nr.Items) > 0 {
+				p.setLastSeenId(nr.Items[0].Id)
+			}
+			return newItems, nil
+		}
+		seen := len(nr.Items) == 0
+		for _, item := range nr.Items {
+			if item.Id <= lastSeenId {
+				seen = true
+				break
+			}
+			newItems = append(newItems, item)
+		}
+		if seen {
+			truncated = false
+			break
+		}
+		next, ok := nr.Cursor.Next()
+		if !ok {
+			truncated = false
+			break
+		}
+		start = next
+	}
+
+	// the newest items come first in the feed
+	for i, j := 0, len(newItems)-1; i < j; i, j = i+1, j-1 {
+		newItems[i], newItems[j] = newItems[j], newItems[i]
+	}
+	if len(newItems) > 0 {
+		p.setLastSeenId(newItems[len(newItems)-1].Id)
+	}
+	if truncated {
+		return newItems, ErrTooManyPages
+	}
+	return newItems, nil
+}
+
+func (p *NewsPoller) setLastSeenId(id int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastSeenId = id
+}
+
+// Run polls the news feed every interval until ctx is done
+// and calls emit for every new item, the oldest item first.
+// The items of a poll are emitted even if the poll returns ErrTooManyPages.
+// It returns the error of ctx.
+func (p *NewsPoller) Run(ctx context.Context, emit func(item NewsItem)) error {
+	if ctx == nil {
+		return ErrNonNilContext
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		items, err := p.Poll(ctx)
+		if err != nil && ctx.Err() == nil && p.OnError != nil {
+			p.OnError(err)
+		}
+		for _, item := range items {
+			emit(item)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func parseNewsResponse(byteData []byte, block string, nr *NewsResponse) error {
+	if nr == nil {
+		return ErrNilPointer
+	}
+	return parseBlockWithCursor(byteData, block, &nr.Cursor, func(data []byte) error {
+		item := NewsItem{}
+		errParse := parseNewsItem(data, &item)
+		if errParse == nil {
+			nr.Items = append(nr.Items, item)
+		}
+		return errParse
+	})
+}
+
+func parseNewsItem(data []byte, item *NewsItem) (err error) {
+	id, err := parseIntWithDefaultValue(data, newsKeyId)
+	if err != nil {
+		return
+	}
+
+	// a single item of the feed has no tag
+	var tag string
+	if hasKey(data, newsKeyTag) {
+		tag, err = parseStringWithDefaultValueByKey(data, newsKeyTag, "")
+		if err != nil {
+			return
+		}
+	}
+
+	title, err := parseStringWithDefaultValueByKey(data, newsKeyTitle, "")
+	if err != nil {
+		return
+	}
+
+	publishedAt, err := parseStringWithDefaultValueByKey(data, newsKeyPublishedAt, "")
+	if err != nil {
+		return
+	}
+
+	var modifiedAt string
+	if hasKey(data, newsKeyModifiedAt) {
+		modifiedAt, err = parseStringWithDefaultValueByKey(data, newsKeyModifiedAt, "")
+		if err != nil {
+			return
+		}
+	}
+
+	pt, err := parseNewsTime(publishedAt)
+	if err != nil {
+		return
+	}
+
+	mt, err := parseNewsTime(modifiedAt)
+	if err != nil {
+		return
+	}
+
+	item.Id = id
+	item.Tag = tag
+	item.Title = title
+	item.PublishedAt = pt
+	item.ModifiedAt = mt
+
+	return
+}
+
+func parseNewsContent(data []byte, nc *NewsContent) (err error) {
+	err = parseNewsItem(data, &nc.NewsItem)
+	if err != nil {
+		return
+	}
+
+	body, err := parseStringWithDefaultValueByKey(data, newsKeyBody, "")
+	if err != nil {
+		return
+	}
+
+	nc.Body = body
+
+	return
+}
+
+// parseNewsTime parses a time of the news feed, an empty value is zero time
+func parseNewsTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(issDateTimeLayout, value, MoscowLocation)
+}
@@ -0,0 +1,87 @@
+package moexiss
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// NewsRequestOptions contains options which can be used as arguments
+// for building requests to get the exchange news and events.
+// MoEx ISS API endpoints:
+//
+// https://iss.moex.com/iss/sitenews
+// https://iss.moex.com/iss/events
+type NewsRequestOptions struct {
+	lang  Language // `lang` query parameter in url.URL
+	start uint64   // `start` query parameter in url.URL
+}
+
+// NewsReqOptionsBuilder represents a builder of NewsRequestOptions struct
+type NewsReqOptionsBuilder struct {
+	options *NewsRequestOptions
+}
+
+// NewNewsReqOptionsBuilder is a constructor of NewsReqOptionsBuilder
+func NewNewsReqOptionsBuilder() *NewsReqOptionsBuilder {
+	return &NewsReqOptionsBuilder{options: &NewsRequestOptions{}}
+}
+
+// Build builds NewsRequestOptions from NewsReqOptionsBuilder
+func (b *NewsReqOptionsBuilder) Build() *NewsRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds NewsRequestOptions from NewsReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *NewsReqOptionsBuilder) BuildValidated() (*NewsRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of NewsRequestOptions,
+// options can be nil, it is safe
+func (options *NewsRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *NewsReqOptionsBuilder) Lang(lang Language) *NewsReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// Start sets 'start' parameter to a request of a list of news or events
+// Row number (the number of the first row is 0) to begin the result set with,
+// the newest items come first.
+// 0 by default
+func (b *NewsReqOptionsBuilder) Start(start uint64) *NewsReqOptionsBuilder {
+	b.options.start = start
+	return b
+}
+
+// addNewsRequestOptions sets parameters into *url.URL
+// from NewsRequestOptions struct and returns it back
+func addNewsRequestOptions(url *url.URL, options *NewsRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+	if options.start != 0 {
+		q.Set("start", strconv.FormatUint(options.start, 10))
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
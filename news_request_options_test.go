@@ -0,0 +1,72 @@
+package moexiss
+
+import (
+	"testing"
+)
+
+func TestNewsReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := NewsRequestOptions{}
+	bld := NewNewsReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` NewsRequestOptions \ngot `%v` NewsRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestNewsReqOptionsBuilder_Lang(t *testing.T) {
+	expectStruct := NewsRequestOptions{lang: LangEn}
+	bld := NewNewsReqOptionsBuilder()
+	bld.Lang(LangEn)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewsReqOptionsBuilder_Start(t *testing.T) {
+	expectStruct := NewsRequestOptions{start: 100}
+	bld := NewNewsReqOptionsBuilder()
+	bld.Start(100)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewNewsRequestOptions(t *testing.T) {
+	expectStruct := NewsRequestOptions{
+		lang:  LangRu,
+		start: 10,
+	}
+	bld := NewNewsReqOptionsBuilder()
+	bld.Lang(LangRu).Start(10)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+
+}
+
+func TestAddNewsRequestOptionsNilOptions(t *testing.T) {
+	var income *NewsRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addNewsRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddNewsRequestOptions(t *testing.T) {
+	var incomeOptions = NewNewsReqOptionsBuilder().
+		Lang(LangEn).
+		Start(100).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addNewsRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&lang=en&start=100`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,382 @@
+package moexiss
+
+import (
+	"context"
+	"fmt"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func getNewsSrv(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fileName string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/sitenews/"):
+			fileName = "sitenews_item.json"
+		case strings.HasPrefix(r.URL.Path, "/events"):
+			fileName = "events.json"
+		default:
+			fileName = "sitenews.json"
+		}
+		byteValue, err := getTestingData(fileName)
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+}
+
+// newsFeedSrv serves the news feed with the items of the ids, the newest first,
+// by pages of pageSize items
+type newsFeedSrv struct {
+	mu       sync.Mutex
+	ids      []int64
+	pageSize int
+	requests int
+}
+
+func (s *newsFeedSrv) publish(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids = append([]int64{id}, s.ids...)
+}
+
+func (s *newsFeedSrv) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	var start int
+	_, _ = fmt.Sscan(r.URL.Query().Get("start"), &start)
+	items := make([]string, 0)
+	for i := start; i < start+s.pageSize && i < len(s.ids); i++ {
+		items = append(items, fmt.Sprintf(`{"id": %d, "tag": "site", "title": "news %d", "published_at": "2024-03-15 18:45:01", "modified_at": null}`, s.ids[i], s.ids[i]))
+	}
+	_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"sitenews": [%s], "sitenews.cursor": [{"INDEX": %d, "TOTAL": %d, "PAGESIZE": %d}]}]`,
+		strings.Join(items, ","), start, len(s.ids), s.pageSize)
+}
+
+func TestNewsGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	type Case struct {
+		feed     NewsFeed
+		id       int64
+		expected string
+	}
+	cases := []Case{
+		{NewsFeedSite, 0, `https://iss.moex.com/iss/sitenews.json?iss.json=extended&iss.meta=off`},
+		{NewsFeedSite, 71245, `https://iss.moex.com/iss/sitenews/71245.json?iss.json=extended&iss.meta=off`},
+		{NewsFeedEvents, 0, `https://iss.moex.com/iss/events.json?iss.json=extended&iss.meta=off`},
+		{NewsFeedEvents, 2711, `https://iss.moex.com/iss/events/2711.json?iss.json=extended&iss.meta=off`},
+	}
+	for i, c1 := range cases {
+		got, err := c.News.getUrl(c1.feed, c1.id, nil)
+		if err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead in %d case", err, i)
+		}
+		if got != c1.expected {
+			t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead in %d case", c1.expected, got, i)
+		}
+	}
+}
+
+func TestNewsGetUrlBadFeed(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.News.getUrl(NewsFeed("news"), 0, nil); err != ErrBadNewsFeedParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadNewsFeedParameter, err)
+	}
+}
+
+func TestParseNewsItem(t *testing.T) {
+	expectedStruct := NewsItem{
+		Id:          71243,
+		Tag:         "site",
+		Title:       "Об изменении лимитов колебаний цен",
+		PublishedAt: time.Date(2024, 3, 15, 16, 5, 42, 0, MoscowLocation),
+	}
+	var incomeJSON = `
+{"id": 71243, "tag": "site", "title": "Об изменении лимитов колебаний цен", "published_at": "2024-03-15 16:05:42", "modified_at": null}
+`
+	item := NewsItem{}
+	if err := parseNewsItem([]byte(incomeJSON), &item); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := item, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseNewsItemErrCases(t *testing.T) {
+	if got, expected := parseNewsItem([]byte(`{"id": 1, "tag": "site", "title": "news"}`), &NewsItem{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if err := parseNewsItem([]byte(`{"id": 1, "title": "news", "published_at": "15.03.2024"}`), &NewsItem{}); err == nil {
+		t.Fatalf("Error: expecting error \ngot <nil> \ninstead")
+	}
+	if got, expected := parseNewsContent([]byte(`{"id": 1, "title": "news", "published_at": "2024-03-15 16:05:42"}`), &NewsContent{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseNewsResponseErrCases(t *testing.T) {
+	if got, expected := parseNewsResponse(nil, NewsFeedSite.String(), nil), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	nr := NewsResponse{}
+	if got, expected := parseNewsResponse([]byte(`[{"sitenews": [[]]}]`), NewsFeedSite.String(), &nr), ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestNewsService_SiteNews(t *testing.T) {
+	srv := getNewsSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	nr, err := c.News.SiteNews(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(nr.Items), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := *nr.Cursor, (Cursor{Index: 0, Total: 39210, PageSize: 50}); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := nr.Items[0].ModifiedAt, time.Date(2024, 3, 15, 18, 47, 12, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := nr.Feed, NewsFeedSite; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestNewsService_SiteNewsItem(t *testing.T) {
+	srv := getNewsSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	nc, err := c.News.SiteNewsItem(context.Background(), 71245, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := nc.Id, int64(71245); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if !strings.HasPrefix(nc.Body, "<p>") {
+		t.Fatalf("Error: expecting HTML body \ngot %v \ninstead", nc.Body)
+	}
+}
+
+func TestNewsService_Events(t *testing.T) {
+	srv := getNewsSrv(t)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	nr, err := c.News.Events(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(nr.Items), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := nr.Items[1].Tag, "events"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestNewsService_ItemErrCases(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.News.Event(context.Background(), 0, nil); err != ErrBadNewsIdParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadNewsIdParameter, err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"content": []}]`))
+	}))
+	defer srv.Close()
+	c = NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.News.Event(context.Background(), 1, nil); err != ErrEmptyServerResult {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrEmptyServerResult, err)
+	}
+}
+
+func TestNewsService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.News.SiteNews(context.Background(), nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestNewsNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	if _, err := c.News.SiteNews(ctx, nil); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+	if err := c.News.NewPoller(NewsFeedSite, time.Second, 0).Run(ctx, func(NewsItem) {}); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
+
+func TestNewsPoller_Poll(t *testing.T) {
+	feed := &newsFeedSrv{ids: []int64{3, 2, 1}, pageSize: 2}
+	srv := httptest.NewServer(feed)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	poller := c.News.NewPoller(NewsFeedSite, time.Second, 0)
+
+	// the first poll only remembers the newest item
+	items, err := poller.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if len(items) != 0 || poller.LastSeenId() != 3 {
+		t.Fatalf("Error: expecting no items and last seen 3 \ngot %v and %v \ninstead", items, poller.LastSeenId())
+	}
+
+	// new items beyond the first page come the oldest first
+	feed.publish(4)
+	feed.publish(5)
+	feed.publish(6)
+	items, err = poller.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	ids := make([]int64, 0)
+	for _, item := range items {
+		ids = append(ids, item.Id)
+	}
+	if got, expected := fmt.Sprint(ids), "[4 5 6]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := poller.LastSeenId(), int64(6); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+
+	items, err = poller.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(items), 0; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+}
+
+func TestNewsPoller_PollTooManyPages(t *testing.T) {
+	feed := &newsFeedSrv{ids: []int64{1}, pageSize: 1}
+	srv := httptest.NewServer(feed)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	poller := c.News.NewPoller(NewsFeedSite, time.Second, 1)
+	last := int64(newsPollerMaxPages + 2)
+	for id := int64(2); id <= last; id++ {
+		feed.publish(id)
+	}
+	items, err := poller.Poll(context.Background())
+	if err != ErrTooManyPages {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrTooManyPages, err)
+	}
+	if got, expected := len(items), newsPollerMaxPages; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := items[0].Id, last-newsPollerMaxPages+1; got != expected {
+		t.Fatalf("Error: expecting the oldest returned item %v \ngot %v \ninstead", expected, got)
+	}
+	if got, expected := poller.LastSeenId(), last; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestNewsPoller_LastSeenIdDuringPoll(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"sitenews": []}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	poller := c.News.NewPoller(NewsFeedSite, time.Second, 7)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = poller.Poll(context.Background())
+	}()
+	<-started
+	// the request of the poll is pending, LastSeenId doesn't wait for it
+	if got, expected := poller.LastSeenId(), int64(7); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	close(release)
+	<-done
+}
+
+func TestNewsPoller_Run(t *testing.T) {
+	feed := &newsFeedSrv{ids: []int64{2, 1}, pageSize: 10}
+	srv := httptest.NewServer(feed)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	poller := c.News.NewPoller(NewsFeedSite, 10*time.Millisecond, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	emitted := make([]int64, 0)
+	err := poller.Run(ctx, func(item NewsItem) {
+		emitted = append(emitted, item.Id)
+		if item.Id == 2 {
+			feed.publish(3)
+		}
+		if item.Id == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", context.Canceled, err)
+	}
+	if got, expected := fmt.Sprint(emitted), "[2 3]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestNewsPoller_RunOnError(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	poller := c.News.NewPoller(NewsFeedSite, 10*time.Millisecond, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var gotErr error
+	poller.OnError = func(err error) {
+		gotErr = err
+		cancel()
+	}
+	if err := poller.Run(ctx, func(NewsItem) {}); err != context.Canceled {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", context.Canceled, err)
+	}
+	if gotErr != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, gotErr)
+	}
+}
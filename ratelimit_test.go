@@ -0,0 +1,55 @@
+package moexiss
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiterNoLimit(t *testing.T) {
+	if got := newRateLimiter(0); got != nil {
+		t.Fatalf("Error: expecting <nil> limiter \ngot %v \ninstead", got)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := newRateLimiter(50)
+	started := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+	}
+	if got, expected := time.Since(started), 40*time.Millisecond; got < expected {
+		t.Fatalf("Error: expecting at least %v \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := newRateLimiter(0.1)
+	_ = l.wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got, expected := l.wait(ctx), context.Canceled; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestClientSetRateLimit(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	c.SetRateLimit(0.1)
+	req, _ := c.NewRequest("GET", "turnovers.json", nil)
+	if _, err := c.BareDo(context.Background(), req); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.BareDo(ctx, req); err != context.Canceled {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", context.Canceled, err)
+	}
+}
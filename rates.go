@@ -0,0 +1,234 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/buger/jsonparser"
+	"path"
+	"time"
+)
+
+// Rate struct represents a MOEX fixing or an indicative rate
+type Rate struct {
+	SecurityId string    // "secid"
+	Time       time.Time // "tradedate" and "tradetime" in MoscowLocation
+	Rate       float64   // "rate"
+	Clearing   string    // "clearing" The clearing session of an indicative rate: "pk" or "vk", it's empty for fixings
+}
+
+// RatesResponse struct represents a response with MOEX fixings or indicative rates
+type RatesResponse struct {
+	SecurityId string // it's empty for all the securities
+	Rates      []Rate
+}
+
+const (
+	ratesFixingPartOfPath     = "fixing"
+	ratesIndicativePartOfPath = "indicativerates"
+	ratesSecuritiesPartOfPath = "securities"
+	ratesFileExtension        = ".json"
+
+	ratesFixingsEndpoint         = "statistics/engines/{engine}/markets/fixing"
+	ratesFixingEndpoint          = "statistics/engines/{engine}/markets/fixing/{security}"
+	ratesIndicativeRatesEndpoint = "statistics/engines/{engine}/markets/indicativerates/securities"
+	ratesIndicativeRateEndpoint  = "statistics/engines/{engine}/markets/indicativerates/securities/{security}"
+
+	rateKeyTradeDate  = "tradedate"
+	rateKeyTradeTime  = "tradetime"
+	rateKeySecId      = "secid"
+	rateKeyRate       = "rate"
+	rateKeyClearing   = "clearing"
+	rateKeySecurities = "securities"
+)
+
+// RatesService gets MOEX currency fixings and indicative rates
+// from the MoEx ISS API.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/statistics/engines/currency/markets/fixing
+// https://iss.moex.com/iss/statistics/engines/futures/markets/indicativerates/securities
+type RatesService service
+
+// GetFixings provides MOEX fixings(e.g. USDFIXME) for the date, see DateRangeReqOptionsBuilder.Date
+func (r *RatesService) GetFixings(ctx context.Context, opt *DateRangeRequestOptions) (*RatesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return r.get(ctx, ratesFixingsEndpoint, r.getFixingUrl("", opt), "")
+}
+
+// GetFixingHistory provides values of the MOEX fixing for the period,
+// see DateRangeReqOptionsBuilder.From and DateRangeReqOptionsBuilder.Till
+func (r *RatesService) GetFixingHistory(ctx context.Context, security string, opt *DateRangeRequestOptions) (*RatesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if !isOkSecurityParam(security) {
+		return nil, ErrBadSecurityParameter
+	}
+	return r.get(ctx, ratesFixingEndpoint, r.getFixingUrl(security, opt), security)
+}
+
+// GetIndicativeRates provides indicative rates of the futures market for the date,
+// see DateRangeReqOptionsBuilder.Date
+func (r *RatesService) GetIndicativeRates(ctx context.Context, opt *DateRangeRequestOptions) (*RatesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return r.get(ctx, ratesIndicativeRatesEndpoint, r.getIndicativeUrl("", opt), "")
+}
+
+// GetIndicativeRateHistory provides values of the indicative rate for the period,
+// see DateRangeReqOptionsBuilder.From and DateRangeReqOptionsBuilder.Till
+func (r *RatesService) GetIndicativeRateHistory(ctx context.Context, security string, opt *DateRangeRequestOptions) (*RatesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if !isOkSecurityParam(security) {
+		return nil, ErrBadSecurityParameter
+	}
+	return r.get(ctx, ratesIndicativeRateEndpoint, r.getIndicativeUrl(security, opt), security)
+}
+
+func (r *RatesService) get(ctx context.Context, endpoint string, url string, security string) (*RatesResponse, error) {
+	req, err := r.client.newEndpointRequest(endpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = r.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	rr := RatesResponse{}
+	err = parseRatesResponse(b.Bytes(), &rr)
+	if err != nil {
+		return nil, err
+	}
+	rr.SecurityId = security
+	return &rr, nil
+}
+
+// getFixingUrl provides an url for a request of MOEX fixings,
+// an empty 'security' means all the fixings
+// opt *DateRangeRequestOptions can be nil, it is safe
+func (r *RatesService) getFixingUrl(security string, opt *DateRangeRequestOptions) string {
+	url, _ := r.client.BaseURL.Parse(statisticsPartOfPath)
+	url.Path = path.Join(url.Path, enginePartOfPath, EngineCurrency.String(), marketsPartOfPath, ratesFixingPartOfPath)
+	if security != "" {
+		url.Path = path.Join(url.Path, security)
+	}
+	url.Path += ratesFileExtension
+	gotURL := addDateRangeRequestOptions(url, opt)
+	return gotURL.String()
+}
+
+// getIndicativeUrl provides an url for a request of indicative rates,
+// an empty 'security' means all the rates
+// opt *DateRangeRequestOptions can be nil, it is safe
+func (r *RatesService) getIndicativeUrl(security string, opt *DateRangeRequestOptions) string {
+	url, _ := r.client.BaseURL.Parse(statisticsPartOfPath)
+	url.Path = path.Join(url.Path, enginePartOfPath, EngineFutures.String(), marketsPartOfPath, ratesIndicativePartOfPath, ratesSecuritiesPartOfPath)
+	if security != "" {
+		url.Path = path.Join(url.Path, security)
+	}
+	url.Path += ratesFileExtension
+	gotURL := addDateRangeRequestOptions(url, opt)
+	return gotURL.String()
+}
+
+func parseRatesResponse(byteData []byte, ratesResponse *RatesResponse) error {
+	var err error
+	if ratesResponse == nil {
+		err = ErrNilPointer
+		return err
+	}
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(ratesBytes []byte, _ jsonparser.ValueType, offset int, errCb error) {
+		var data []byte
+		var dataType jsonparser.ValueType
+		data, dataType, _, errInCb = jsonparser.Get(ratesBytes, rateKeySecurities)
+		if errInCb == nil && data != nil && dataType == jsonparser.Array {
+			errInCb = parseRates(data, &ratesResponse.Rates)
+			if errInCb != nil {
+				return
+			}
+		}
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return err
+}
+
+func parseRates(byteData []byte, rates *[]Rate) (err error) {
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(rateItemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.Object {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+
+		rate := Rate{}
+		errInCb = parseRate(rateItemData, &rate)
+		if errInCb != nil {
+			return
+		}
+		*rates = append(*rates, rate)
+
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return
+}
+
+func parseRate(data []byte, r *Rate) (err error) {
+	tradeDate, err := parseStringWithDefaultValueByKey(data, rateKeyTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	tradeTime, err := parseStringWithDefaultValueByKey(data, rateKeyTradeTime, "")
+	if err != nil {
+		return
+	}
+
+	secId, err := parseStringWithDefaultValueByKey(data, rateKeySecId, "")
+	if err != nil {
+		return
+	}
+
+	rate, err := parseFloatWithDefaultValue(data, rateKeyRate)
+	if err != nil {
+		return
+	}
+
+	// fixings have no clearing sessions
+	var clearing string
+	if hasKey(data, rateKeyClearing) {
+		clearing, err = parseStringWithDefaultValueByKey(data, rateKeyClearing, "")
+		if err != nil {
+			return
+		}
+	}
+
+	t, err := parseDateTimeWithDefaultValue(tradeDate, tradeTime)
+	if err != nil {
+		return
+	}
+
+	r.SecurityId = secId
+	r.Time = t
+	r.Rate = rate
+	r.Clearing = clearing
+
+	return
+}
@@ -0,0 +1,207 @@
+package moexiss
+
+import (
+	"context"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRatesGetFixingUrl(t *testing.T) {
+	c := NewClient(nil)
+	if got, expected := c.Rates.getFixingUrl("", nil), `https://iss.moex.com/iss/statistics/engines/currency/markets/fixing.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+	opt := NewDateRangeReqOptionsBuilder().From(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)).Build()
+	if got, expected := c.Rates.getFixingUrl("USDFIXME", opt), `https://iss.moex.com/iss/statistics/engines/currency/markets/fixing/USDFIXME.json?from=2022-03-01&iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestRatesGetIndicativeUrl(t *testing.T) {
+	c := NewClient(nil)
+	if got, expected := c.Rates.getIndicativeUrl("", nil), `https://iss.moex.com/iss/statistics/engines/futures/markets/indicativerates/securities.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+	if got, expected := c.Rates.getIndicativeUrl("USD/RUB", nil), `https://iss.moex.com/iss/statistics/engines/futures/markets/indicativerates/securities/USD/RUB.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	expectedStruct := Rate{
+		SecurityId: "USD/RUB",
+		Time:       time.Date(2022, 3, 4, 13, 45, 0, 0, MoscowLocation),
+		Rate:       105.5225,
+		Clearing:   "pk",
+	}
+	var incomeJSON = `
+{"tradedate": "2022-03-04", "tradetime": "13:45:00", "secid": "USD/RUB", "rate": 105.5225, "clearing": "pk"}
+`
+	r := Rate{}
+	err := parseRate([]byte(incomeJSON), &r)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := r, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseRateErrCases(t *testing.T) {
+	cases := []string{
+		// no tradedate
+		`{"tradedate1": "2022-03-04", "tradetime": "12:30:00", "secid": "USDFIXME", "rate": 105.8116}`,
+		// no tradetime
+		`{"tradedate": "2022-03-04", "tradetime1": "12:30:00", "secid": "USDFIXME", "rate": 105.8116}`,
+		// no secid
+		`{"tradedate": "2022-03-04", "tradetime": "12:30:00", "secid1": "USDFIXME", "rate": 105.8116}`,
+		// no rate
+		`{"tradedate": "2022-03-04", "tradetime": "12:30:00", "secid": "USDFIXME", "rate1": 105.8116}`,
+	}
+	for i, c := range cases {
+		r := Rate{}
+		if got, expected := parseRate([]byte(c), &r), jsonparser.KeyPathNotFoundError; got != expected {
+			t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
+
+func TestParseRateBadTime(t *testing.T) {
+	var incomeJSON = `{"tradedate": "2022-03-04", "tradetime": "12:30", "secid": "USDFIXME", "rate": 105.8116}`
+	r := Rate{}
+	if err := parseRate([]byte(incomeJSON), &r); err == nil {
+		t.Fatalf("Error: expecting an error \ngot <nil> \ninstead")
+	}
+}
+
+func TestParseRatesUnexpectedDataTypeError(t *testing.T) {
+	rates := make([]Rate, 0)
+	if got, expected := parseRates([]byte(`[[]]`), &rates), ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseRatesResponseNilError(t *testing.T) {
+	var ratesResponse *RatesResponse = nil
+	if got, expected := parseRatesResponse([]byte(``), ratesResponse), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseRatesResponse(t *testing.T) {
+	byteValue, err := getTestingData("indicativerates.json")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	rr := RatesResponse{}
+	err = parseRatesResponse(byteValue, &rr)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(rr.Rates), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := rr.Rates[1].Clearing, "vk"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestRatesService_GetFixings(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		byteValue, _ := getTestingData("fixing.json")
+		_, _ = w.Write(byteValue)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.Rates.GetFixings(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Rates), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	expectedRate := Rate{SecurityId: "USDFIXME", Time: time.Date(2022, 3, 4, 12, 30, 0, 0, MoscowLocation), Rate: 105.8116}
+	if got, expected := result.Rates[0], expectedRate; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+
+	result, err = c.Rates.GetFixingHistory(context.Background(), "USDFIXME", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := gotPath, "/statistics/engines/currency/markets/fixing/USDFIXME.json"; got != expected {
+		t.Fatalf("Error: expecting %v path \ngot %v \ninstead", expected, got)
+	}
+	if got, expected := result.SecurityId, "USDFIXME"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestRatesService_GetIndicativeRates(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		byteValue, _ := getTestingData("indicativerates.json")
+		_, _ = w.Write(byteValue)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.Rates.GetIndicativeRates(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Rates), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+
+	_, err = c.Rates.GetIndicativeRateHistory(context.Background(), "USD/RUB", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := gotPath, "/statistics/engines/futures/markets/indicativerates/securities/USD/RUB.json"; got != expected {
+		t.Fatalf("Error: expecting %v path \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestRatesService_BadSecurityParam(t *testing.T) {
+	c := NewClient(nil)
+	_, err := c.Rates.GetFixingHistory(context.Background(), "", nil)
+	if got, expected := err, ErrBadSecurityParameter; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+	_, err = c.Rates.GetIndicativeRateHistory(context.Background(), "u", nil)
+	if got, expected := err, ErrBadSecurityParameter; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestRatesService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	_, err := c.Rates.GetFixings(context.Background(), nil)
+	if got, expected := err, jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestRatesNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	_, err := c.Rates.GetIndicativeRates(ctx, nil)
+	if got, expected := err, ErrNonNilContext; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,196 @@
+package moexiss
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DanglingReference represents a field of an item of the index
+// which refers to an item that is absent in the index
+type DanglingReference struct {
+	Table string // the block of the item, e.g. "boards"
+	Key   string // the key of the item, e.g. "TQBR" for a board or "5" for an item with the id
+	Field string // the field which refers to the absent item, e.g. "market_id"
+	Value string // the absent key, e.g. "999"
+}
+
+// String returns a description of the dangling reference
+func (d DanglingReference) String() string {
+	return fmt.Sprintf("%s[%s].%s=%s", d.Table, d.Key, d.Field, d.Value)
+}
+
+// IntegrityError is returned by Reference.Validate if the index has dangling references
+type IntegrityError struct {
+	Dangling []DanglingReference
+}
+
+// Error returns a description of all the dangling references
+func (e *IntegrityError) Error() string {
+	refs := make([]string, len(e.Dangling))
+	for i, d := range e.Dangling {
+		refs[i] = d.String()
+	}
+	return fmt.Sprintf("the index has %d dangling reference(s): %s", len(e.Dangling), strings.Join(refs, ", "))
+}
+
+// Reference is a navigable view of the Index,
+// all the lookups are done by maps instead of linear scans over the slices of the Index.
+// Reference is safe for concurrent use because it's never changed after NewReference
+type Reference struct {
+	engines              map[int64]Engine
+	markets              map[int64]Market
+	boards               map[string]Board
+	boardGroups          map[int64]BoardGroup
+	securityGroupsById   map[int64]SecurityGroup
+	securityGroupsByName map[string]SecurityGroup
+	boardsByGroup        map[int64][]Board
+	boardsByMarket       map[int64][]Board
+	securityTypesByGroup map[string][]SecurityType
+	index                *Index
+}
+
+// NewReference builds the reference of the index,
+// the index must not be changed after that
+func NewReference(index *Index) (*Reference, error) {
+	if index == nil {
+		return nil, ErrNilPointer
+	}
+	r := &Reference{
+		engines:              make(map[int64]Engine, len(index.Engines)),
+		markets:              make(map[int64]Market, len(index.Markets)),
+		boards:               make(map[string]Board, len(index.Boards)),
+		boardGroups:          make(map[int64]BoardGroup, len(index.BoardGroups)),
+		securityGroupsById:   make(map[int64]SecurityGroup, len(index.SecurityGroups)),
+		securityGroupsByName: make(map[string]SecurityGroup, len(index.SecurityGroups)),
+		boardsByGroup:        make(map[int64][]Board),
+		boardsByMarket:       make(map[int64][]Board),
+		securityTypesByGroup: make(map[string][]SecurityType),
+		index:                index,
+	}
+	for _, e := range index.Engines {
+		r.engines[e.Id] = e
+	}
+	for _, m := range index.Markets {
+		r.markets[m.Id] = m
+	}
+	for _, b := range index.Boards {
+		r.boards[b.BoardId] = b
+		r.boardsByGroup[b.BoardGroupId] = append(r.boardsByGroup[b.BoardGroupId], b)
+		r.boardsByMarket[b.MarketId] = append(r.boardsByMarket[b.MarketId], b)
+	}
+	for _, bg := range index.BoardGroups {
+		r.boardGroups[bg.Id] = bg
+	}
+	for _, sg := range index.SecurityGroups {
+		r.securityGroupsById[sg.Id] = sg
+		r.securityGroupsByName[sg.Name] = sg
+	}
+	for _, st := range index.SecurityTypes {
+		r.securityTypesByGroup[st.SecurityGroupName] = append(r.securityTypesByGroup[st.SecurityGroupName], st)
+	}
+	return r, nil
+}
+
+// BoardByID returns the board by its code, e.g. "TQBR"
+func (r *Reference) BoardByID(boardId string) (Board, bool) {
+	b, ok := r.boards[boardId]
+	return b, ok
+}
+
+// MarketOf returns the market of the board
+func (r *Reference) MarketOf(board Board) (Market, bool) {
+	m, ok := r.markets[board.MarketId]
+	return m, ok
+}
+
+// EngineOf returns the engine of the market
+func (r *Reference) EngineOf(market Market) (Engine, bool) {
+	e, ok := r.engines[market.Engine.Id]
+	return e, ok
+}
+
+// BoardGroupOf returns the board group of the board
+func (r *Reference) BoardGroupOf(board Board) (BoardGroup, bool) {
+	bg, ok := r.boardGroups[board.BoardGroupId]
+	return bg, ok
+}
+
+// BoardsOfGroup returns the boards of the board group in the order of the index
+func (r *Reference) BoardsOfGroup(group BoardGroup) []Board {
+	return append([]Board{}, r.boardsByGroup[group.Id]...)
+}
+
+// SecurityTypesOfGroup returns the security types of the security group in the order of the index
+func (r *Reference) SecurityTypesOfGroup(group SecurityGroup) []SecurityType {
+	return append([]SecurityType{}, r.securityTypesByGroup[group.Name]...)
+}
+
+// PrimaryBoard returns the primary board of the market.
+// MoEx ISS API marks several boards of a market as primary,
+// a traded board is preferred and then the board with the least id
+func (r *Reference) PrimaryBoard(market Market) (Board, bool) {
+	var primary Board
+	found := false
+	for _, b := range r.boardsByMarket[market.Id] {
+		if !b.IsPrimary {
+			continue
+		}
+		if !found || b.IsTraded && !primary.IsTraded || b.IsTraded == primary.IsTraded && b.Id < primary.Id {
+			primary = b
+			found = true
+		}
+	}
+	return primary, found
+}
+
+// Validate checks the referential integrity of the index,
+// it returns *IntegrityError with all the dangling references or nil
+func (r *Reference) Validate() error {
+	dangling := r.danglingReferences()
+	if len(dangling) == 0 {
+		return nil
+	}
+	return &IntegrityError{Dangling: dangling}
+}
+
+// danglingReferences returns the dangling references in the order of the blocks of the index
+func (r *Reference) danglingReferences() []DanglingReference {
+	dangling := make([]DanglingReference, 0)
+	checkId := func(table string, key string, field string, value int64, exists bool) {
+		if !exists {
+			dangling = append(dangling, DanglingReference{Table: table, Key: key, Field: field, Value: strconv.FormatInt(value, 10)})
+		}
+	}
+
+	for _, m := range r.index.Markets {
+		_, ok := r.engines[m.Engine.Id]
+		checkId(keyMarkets, idKey(m.Id), "trade_engine_id", m.Engine.Id, ok)
+	}
+	for _, b := range r.index.Boards {
+		_, ok := r.boardGroups[b.BoardGroupId]
+		checkId(keyBoards, b.BoardId, "board_group_id", b.BoardGroupId, ok)
+		_, ok = r.engines[b.EngineId]
+		checkId(keyBoards, b.BoardId, "engine_id", b.EngineId, ok)
+		_, ok = r.markets[b.MarketId]
+		checkId(keyBoards, b.BoardId, "market_id", b.MarketId, ok)
+	}
+	for _, bg := range r.index.BoardGroups {
+		_, ok := r.engines[bg.Engine.Id]
+		checkId(keyBoardGroups, idKey(bg.Id), "trade_engine_id", bg.Engine.Id, ok)
+		_, ok = r.markets[bg.MarketId]
+		checkId(keyBoardGroups, idKey(bg.Id), "market_id", bg.MarketId, ok)
+	}
+	for _, st := range r.index.SecurityTypes {
+		_, ok := r.engines[st.Engine.Id]
+		checkId(keySecurityTypes, idKey(st.Id), "trade_engine_id", st.Engine.Id, ok)
+		if _, ok = r.securityGroupsByName[st.SecurityGroupName]; !ok {
+			dangling = append(dangling, DanglingReference{Table: keySecurityTypes, Key: idKey(st.Id), Field: "security_group_name", Value: st.SecurityGroupName})
+		}
+	}
+	for _, sc := range r.index.SecurityCollections {
+		_, ok := r.securityGroupsById[sc.SecurityGroupId]
+		checkId(keySecurityCollections, idKey(sc.Id), "security_group_id", sc.SecurityGroupId, ok)
+	}
+	return dangling
+}
@@ -0,0 +1,130 @@
+package moexiss
+
+import (
+	"errors"
+	"testing"
+)
+
+func getTestingReference(t *testing.T) *Reference {
+	r, err := NewReference(getTestingIndex(t))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	return r
+}
+
+func getDanglingIndex() *Index {
+	index := newIndex()
+	index.Engines = append(index.Engines, Engine{GeneralFields{Id: 1, Name: "stock"}})
+	index.Markets = append(index.Markets,
+		Market{Engine: Engine{GeneralFields{Id: 1}}, GeneralFields: GeneralFields{Id: 1, Name: "shares"}},
+		Market{Engine: Engine{GeneralFields{Id: 7}}, GeneralFields: GeneralFields{Id: 2, Name: "bonds"}})
+	index.BoardGroups = append(index.BoardGroups, BoardGroup{Engine: Engine{GeneralFields{Id: 1}}, MarketId: 3, GeneralFields: GeneralFields{Id: 57}})
+	index.Boards = append(index.Boards,
+		Board{Id: 129, BoardGroupId: 57, EngineId: 1, MarketId: 1, BoardId: "TQBR"},
+		Board{Id: 130, BoardGroupId: 58, EngineId: 1, MarketId: 1, BoardId: "TQBS"})
+	index.SecurityGroups = append(index.SecurityGroups, SecurityGroup{GeneralFields: GeneralFields{Id: 4, Name: "stock_shares"}})
+	index.SecurityTypes = append(index.SecurityTypes, SecurityType{Engine: Engine{GeneralFields{Id: 1}}, GeneralFields: GeneralFields{Id: 3}, SecurityGroupName: "stock_dr"})
+	index.SecurityCollections = append(index.SecurityCollections, SecurityCollection{GeneralFields: GeneralFields{Id: 10}, SecurityGroupId: 5})
+	return index
+}
+
+func TestNewReferenceNilIndex(t *testing.T) {
+	if _, got := NewReference(nil); got != ErrNilPointer {
+		t.Fatalf("Error: expecting error %v \ngot %v \ninstead", ErrNilPointer, got)
+	}
+}
+
+func TestReferenceNavigation(t *testing.T) {
+	r := getTestingReference(t)
+
+	board, ok := r.BoardByID("TQBR")
+	if !ok || board.Id != 129 {
+		t.Fatalf("Error: expecting board TQBR with id 129 \ngot %v, %v \ninstead", board, ok)
+	}
+	market, ok := r.MarketOf(board)
+	if !ok || market.Name != "shares" {
+		t.Fatalf("Error: expecting market 'shares' \ngot %v, %v \ninstead", market, ok)
+	}
+	engine, ok := r.EngineOf(market)
+	if !ok || engine.Name != "stock" {
+		t.Fatalf("Error: expecting engine 'stock' \ngot %v, %v \ninstead", engine, ok)
+	}
+	group, ok := r.BoardGroupOf(board)
+	if !ok || group.Name != "stock_shares_tplus" {
+		t.Fatalf("Error: expecting board group 'stock_shares_tplus' \ngot %v, %v \ninstead", group, ok)
+	}
+	found := false
+	for _, b := range r.BoardsOfGroup(group) {
+		if b.BoardGroupId != group.Id {
+			t.Fatalf("Error: expecting boards of the group %d \ngot %v \ninstead", group.Id, b)
+		}
+		found = found || b.BoardId == "TQBR"
+	}
+	if !found {
+		t.Fatalf("Error: expecting TQBR among the boards of the group %d", group.Id)
+	}
+	if _, ok := r.BoardByID("NOTEXIST"); ok {
+		t.Fatalf("Error: expecting no board NOTEXIST")
+	}
+}
+
+func TestReferencePrimaryBoard(t *testing.T) {
+	r := getTestingReference(t)
+
+	board, _ := r.BoardByID("TQBR")
+	market, _ := r.MarketOf(board)
+	primary, ok := r.PrimaryBoard(market)
+	if !ok || primary.BoardId != "TQBR" {
+		t.Fatalf("Error: expecting primary board TQBR \ngot %v, %v \ninstead", primary, ok)
+	}
+	if _, ok := r.PrimaryBoard(Market{GeneralFields: GeneralFields{Id: -1}}); ok {
+		t.Fatalf("Error: expecting no primary board of an unknown market")
+	}
+}
+
+func TestReferenceSecurityTypesOfGroup(t *testing.T) {
+	r := getTestingReference(t)
+
+	for _, sg := range r.index.SecurityGroups {
+		for _, st := range r.SecurityTypesOfGroup(sg) {
+			if st.SecurityGroupName != sg.Name {
+				t.Fatalf("Error: expecting security types of the group %s \ngot %v \ninstead", sg.Name, st)
+			}
+		}
+	}
+	got := r.SecurityTypesOfGroup(SecurityGroup{GeneralFields: GeneralFields{Name: "stock_shares"}})
+	if len(got) == 0 {
+		t.Fatalf("Error: expecting security types of the group stock_shares")
+	}
+}
+
+func TestReferenceValidate(t *testing.T) {
+	if err := getTestingReference(t).Validate(); err != nil {
+		t.Fatalf("Error: expecting no dangling references \ngot %v \ninstead", err)
+	}
+}
+
+func TestReferenceValidateDangling(t *testing.T) {
+	r, _ := NewReference(getDanglingIndex())
+	err := r.Validate()
+	var integrityErr *IntegrityError
+	if !errors.As(err, &integrityErr) {
+		t.Fatalf("Error: expecting *IntegrityError \ngot %v \ninstead", err)
+	}
+	expected := []string{
+		"markets[2].trade_engine_id=7",
+		"boards[TQBS].board_group_id=58",
+		"boardgroups[57].market_id=3",
+		"securitytypes[3].security_group_name=stock_dr",
+		"securitycollections[10].security_group_id=5",
+	}
+	if len(integrityErr.Dangling) != len(expected) {
+		t.Fatalf("Error: expecting %d dangling references \ngot %v \ninstead", len(expected), integrityErr.Dangling)
+	}
+	for i, d := range integrityErr.Dangling {
+		if got := d.String(); got != expected[i] {
+			t.Fatalf("Error: expecting dangling reference %s \ngot %s \ninstead", expected[i], got)
+		}
+	}
+}
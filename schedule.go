@@ -0,0 +1,293 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"time"
+)
+
+// WeekDaySchedule struct represents trading hours of a day of the week
+type WeekDaySchedule struct {
+	WeekDay   time.Weekday  // "week_day" MoEx ISS API counts days from 1(Monday) to 7(Sunday)
+	IsWorkDay bool          // "is_work_day"
+	Start     time.Duration // "start_time" since midnight in MoscowLocation
+	Stop      time.Duration // "stop_time" since midnight in MoscowLocation
+}
+
+// DaySchedule struct represents trading hours of an exceptional day(e.g. a holiday or a working weekend)
+// which override the hours of its day of the week
+type DaySchedule struct {
+	Date      time.Time     // "date" in MoscowLocation
+	IsWorkDay bool          // "is_work_day"
+	Start     time.Duration // "start_time" since midnight in MoscowLocation
+	Stop      time.Duration // "stop_time" since midnight in MoscowLocation
+}
+
+// SessionHours struct represents the start and the stop of a trading session of a day
+type SessionHours struct {
+	Start time.Time // in MoscowLocation
+	Stop  time.Time // in MoscowLocation
+}
+
+// TradingSchedule struct represents a trading schedule of an engine
+type TradingSchedule struct {
+	Engine          Engine            // "engine" block
+	WeekDays        []WeekDaySchedule // "timetable" block
+	ExceptionalDays []DaySchedule     // "dailytable" block
+}
+
+const (
+	scheduleFileExtension = ".json"
+	scheduleEndpoint      = "engines/{engine}"
+
+	scheduleKeyEngine     = "engine"
+	scheduleKeyTimetable  = "timetable"
+	scheduleKeyDailytable = "dailytable"
+	scheduleKeyWeekDay    = "week_day"
+	scheduleKeyDate       = "date"
+	scheduleKeyIsWorkDay  = "is_work_day"
+	scheduleKeyStartTime  = "start_time"
+	scheduleKeyStopTime   = "stop_time"
+
+	// scheduleLookAheadDays limits the search of the next session
+	scheduleLookAheadDays = 366
+)
+
+// ScheduleService gets a trading schedule of an engine
+// from the MoEx ISS API.
+//
+// MoEx ISS API endpoint:
+// https://iss.moex.com/iss/engines/[engine]
+type ScheduleService service
+
+// Schedule provides the trading schedule of the engine
+func (s *ScheduleService) Schedule(ctx context.Context, engine EngineName, opt *ScheduleRequestOptions) (*TradingSchedule, error) {
+	url, err := s.getUrl(engine, opt)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.newEndpointRequest(scheduleEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = s.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	ts := TradingSchedule{WeekDays: make([]WeekDaySchedule, 0), ExceptionalDays: make([]DaySchedule, 0)}
+	err = parseTradingSchedule(b.Bytes(), &ts)
+	if err != nil {
+		return nil, err
+	}
+	if ts.Engine.Name == "" {
+		ts.Engine.Name = engine.String()
+	}
+	return &ts, nil
+}
+
+// getUrl provides an url for a request of the trading schedule of the engine
+// opt *ScheduleRequestOptions can be nil, it is safe
+func (s *ScheduleService) getUrl(engine EngineName, opt *ScheduleRequestOptions) (string, error) {
+	if engine == EngineUndefined {
+		return "", ErrBadEngineParameter
+	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
+	url, _ := s.client.BaseURL.Parse(enginePartOfPath)
+	url.Path = path.Join(url.Path, engine.String())
+	url.Path += scheduleFileExtension
+	gotURL := addScheduleRequestOptions(url, opt)
+	return gotURL.String(), nil
+}
+
+// IsTradingDay reports whether the date in MoscowLocation is a working day,
+// an exceptional day overrides its day of the week
+func (ts *TradingSchedule) IsTradingDay(date time.Time) bool {
+	isWorkDay, _, _, ok := ts.hoursOf(date)
+	return ok && isWorkDay
+}
+
+// SessionAt returns the trading session which is open at the time,
+// the session includes its start and excludes its stop.
+// It returns false if the market is closed at the time.
+func (ts *TradingSchedule) SessionAt(t time.Time) (SessionHours, bool) {
+	t = t.In(MoscowLocation)
+	// a session which stops after midnight belongs to the previous day
+	for _, day := range []time.Time{t.AddDate(0, 0, -1), t} {
+		session, ok := ts.sessionOf(day)
+		if ok && !t.Before(session.Start) && t.Before(session.Stop) {
+			return session, true
+		}
+	}
+	return SessionHours{}, false
+}
+
+// NextSessionOpen returns the start of the first trading session which starts after 'now'.
+// It returns false if there is no working day within a year.
+func (ts *TradingSchedule) NextSessionOpen(now time.Time) (time.Time, bool) {
+	now = now.In(MoscowLocation)
+	for i := 0; i <= scheduleLookAheadDays; i++ {
+		session, ok := ts.sessionOf(now.AddDate(0, 0, i))
+		if ok && session.Start.After(now) {
+			return session.Start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sessionOf returns the trading session of the date in MoscowLocation
+func (ts *TradingSchedule) sessionOf(date time.Time) (SessionHours, bool) {
+	isWorkDay, start, stop, ok := ts.hoursOf(date)
+	if !ok || !isWorkDay {
+		return SessionHours{}, false
+	}
+	date = date.In(MoscowLocation)
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, MoscowLocation)
+	if stop <= start {
+		stop += 24 * time.Hour
+	}
+	return SessionHours{Start: midnight.Add(start), Stop: midnight.Add(stop)}, true
+}
+
+// hoursOf returns trading hours of the date in MoscowLocation,
+// it returns false if the schedule knows nothing about the date
+func (ts *TradingSchedule) hoursOf(date time.Time) (isWorkDay bool, start time.Duration, stop time.Duration, ok bool) {
+	date = date.In(MoscowLocation)
+	for _, d := range ts.ExceptionalDays {
+		if sameDate(d.Date, date) {
+			return d.IsWorkDay, d.Start, d.Stop, true
+		}
+	}
+	for _, d := range ts.WeekDays {
+		if d.WeekDay == date.Weekday() {
+			return d.IsWorkDay, d.Start, d.Stop, true
+		}
+	}
+	return false, 0, 0, false
+}
+
+func parseTradingSchedule(byteData []byte, ts *TradingSchedule) error {
+	if ts == nil {
+		return ErrNilPointer
+	}
+	err := parseBlockWithCursor(byteData, scheduleKeyEngine, nil, func(data []byte) error {
+		return parseEnginesEngine(data, &ts.Engine)
+	})
+	if err != nil {
+		return err
+	}
+	err = parseBlockWithCursor(byteData, scheduleKeyTimetable, nil, func(data []byte) error {
+		wd := WeekDaySchedule{}
+		errParse := parseWeekDaySchedule(data, &wd)
+		if errParse == nil {
+			ts.WeekDays = append(ts.WeekDays, wd)
+		}
+		return errParse
+	})
+	if err != nil {
+		return err
+	}
+	return parseBlockWithCursor(byteData, scheduleKeyDailytable, nil, func(data []byte) error {
+		ds := DaySchedule{}
+		errParse := parseDaySchedule(data, &ds)
+		if errParse == nil {
+			ts.ExceptionalDays = append(ts.ExceptionalDays, ds)
+		}
+		return errParse
+	})
+}
+
+func parseWeekDaySchedule(data []byte, wd *WeekDaySchedule) (err error) {
+	weekDay, err := parseIntWithDefaultValue(data, scheduleKeyWeekDay)
+	if err != nil {
+		return
+	}
+	if weekDay < 1 || weekDay > 7 {
+		return ErrUnexpectedDataType
+	}
+
+	isWorkDay, start, stop, err := parseScheduleHours(data)
+	if err != nil {
+		return
+	}
+
+	wd.WeekDay = time.Weekday(weekDay % 7)
+	wd.IsWorkDay = isWorkDay
+	wd.Start = start
+	wd.Stop = stop
+
+	return
+}
+
+func parseDaySchedule(data []byte, ds *DaySchedule) (err error) {
+	dateStr, err := parseStringWithDefaultValueByKey(data, scheduleKeyDate, "")
+	if err != nil {
+		return
+	}
+
+	isWorkDay, start, stop, err := parseScheduleHours(data)
+	if err != nil {
+		return
+	}
+
+	date, err := parseDateTimeWithDefaultValue(dateStr, "")
+	if err != nil {
+		return
+	}
+
+	ds.Date = date
+	ds.IsWorkDay = isWorkDay
+	ds.Start = start
+	ds.Stop = stop
+
+	return
+}
+
+func parseScheduleHours(data []byte) (isWorkDay bool, start time.Duration, stop time.Duration, err error) {
+	workDay, err := parseIntWithDefaultValue(data, scheduleKeyIsWorkDay)
+	if err != nil {
+		return
+	}
+
+	startStr, err := parseStringWithDefaultValueByKey(data, scheduleKeyStartTime, "")
+	if err != nil {
+		return
+	}
+
+	stopStr, err := parseStringWithDefaultValueByKey(data, scheduleKeyStopTime, "")
+	if err != nil {
+		return
+	}
+
+	if start, err = parseClock(startStr); err != nil {
+		return
+	}
+	if stop, err = parseClock(stopStr); err != nil {
+		return
+	}
+	isWorkDay = workDay == 1
+
+	return
+}
+
+// parseClock parses "hh:mm:ss" into a duration since midnight, "24:00:00" is allowed
+// and an empty string means midnight
+func parseClock(clock string) (time.Duration, error) {
+	if clock == "" {
+		return 0, nil
+	}
+	var h, m, s int
+	n, err := fmt.Sscanf(clock, "%d:%d:%d", &h, &m, &s)
+	if err != nil || n != 3 || h < 0 || h > 24 || m < 0 || m > 59 || s < 0 || s > 59 {
+		return 0, fmt.Errorf("bad time of the day %q", clock)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second, nil
+}
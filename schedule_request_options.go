@@ -0,0 +1,70 @@
+package moexiss
+
+import "net/url"
+
+// ScheduleRequestOptions contains options which can be used as arguments
+// for building requests to get a trading schedule of an engine.
+// MoEx ISS API endpoint:
+//
+// https://iss.moex.com/iss/engines/[engine]
+type ScheduleRequestOptions struct {
+	lang Language // `lang` query parameter in url.URL
+}
+
+// ScheduleReqOptionsBuilder represents a builder of ScheduleRequestOptions struct
+type ScheduleReqOptionsBuilder struct {
+	options *ScheduleRequestOptions
+}
+
+// NewScheduleReqOptionsBuilder is a constructor of ScheduleReqOptionsBuilder
+func NewScheduleReqOptionsBuilder() *ScheduleReqOptionsBuilder {
+	return &ScheduleReqOptionsBuilder{options: &ScheduleRequestOptions{}}
+}
+
+// Build builds ScheduleRequestOptions from ScheduleReqOptionsBuilder
+func (b *ScheduleReqOptionsBuilder) Build() *ScheduleRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds ScheduleRequestOptions from ScheduleReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *ScheduleReqOptionsBuilder) BuildValidated() (*ScheduleRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of ScheduleRequestOptions,
+// options can be nil, it is safe
+func (options *ScheduleRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *ScheduleReqOptionsBuilder) Lang(lang Language) *ScheduleReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// addScheduleRequestOptions sets parameters into *url.URL
+// from ScheduleRequestOptions struct and returns it back
+func addScheduleRequestOptions(url *url.URL, options *ScheduleRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
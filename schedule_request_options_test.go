@@ -0,0 +1,61 @@
+package moexiss
+
+import (
+	"testing"
+)
+
+func TestScheduleReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := ScheduleRequestOptions{}
+	bld := NewScheduleReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` ScheduleRequestOptions \ngot `%v` ScheduleRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestScheduleReqOptionsBuilder_Lang(t *testing.T) {
+	expectStruct := ScheduleRequestOptions{lang: LangEn}
+	bld := NewScheduleReqOptionsBuilder()
+	bld.Lang(LangEn)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewScheduleRequestOptions(t *testing.T) {
+	expectStruct := ScheduleRequestOptions{
+		lang: LangRu,
+	}
+	bld := NewScheduleReqOptionsBuilder()
+	bld.Lang(LangRu)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+
+}
+
+func TestAddScheduleRequestOptionsNilOptions(t *testing.T) {
+	var income *ScheduleRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addScheduleRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddScheduleRequestOptions(t *testing.T) {
+	var incomeOptions = NewScheduleReqOptionsBuilder().
+		Lang(LangEn).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addScheduleRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&lang=en`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
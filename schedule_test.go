@@ -0,0 +1,229 @@
+package moexiss
+
+import (
+	"context"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func getTestingSchedule(t *testing.T) *TradingSchedule {
+	byteValue, err := getTestingData("engine_stock.json")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	ts := TradingSchedule{}
+	if err = parseTradingSchedule(byteValue, &ts); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	return &ts
+}
+
+func TestScheduleGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	got, err := c.Schedule.getUrl(EngineStock, NewScheduleReqOptionsBuilder().Lang(LangEn).Build())
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if expected := `https://iss.moex.com/iss/engines/stock.json?iss.json=extended&iss.meta=off&lang=en`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+	if _, err = c.Schedule.getUrl(EngineUndefined, nil); err != ErrBadEngineParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadEngineParameter, err)
+	}
+}
+
+func TestParseTradingSchedule(t *testing.T) {
+	ts := getTestingSchedule(t)
+	if got, expected := ts.Engine.Name, "stock"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := len(ts.WeekDays), 7; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	expectedDay := WeekDaySchedule{WeekDay: time.Sunday, Start: 9*time.Hour + 50*time.Minute, Stop: 19 * time.Hour}
+	if got, expected := ts.WeekDays[6], expectedDay; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := len(ts.ExceptionalDays), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := ts.ExceptionalDays[1].Date, time.Date(2024, 4, 27, 0, 0, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseTradingScheduleErrCases(t *testing.T) {
+	if got, expected := parseTradingSchedule(nil, nil), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseWeekDaySchedule([]byte(`{"week_day": 8, "is_work_day": 1, "start_time": "06:50:00", "stop_time": "23:50:00"}`), &WeekDaySchedule{}), ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseDaySchedule([]byte(`{"date": "2024-05-01", "start_time": "06:50:00", "stop_time": "23:50:00"}`), &DaySchedule{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if err := parseDaySchedule([]byte(`{"date": "2024-05-01", "is_work_day": 1, "start_time": "6:5", "stop_time": "23:50:00"}`), &DaySchedule{}); err == nil {
+		t.Fatalf("Error: expecting error \ngot <nil> \ninstead")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	type Case struct {
+		income   string
+		expected time.Duration
+	}
+	cases := []Case{
+		{"", 0},
+		{"00:00:00", 0},
+		{"06:50:30", 6*time.Hour + 50*time.Minute + 30*time.Second},
+		{"24:00:00", 24 * time.Hour},
+	}
+	for i, c := range cases {
+		got, err := parseClock(c.income)
+		if err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead in %d case", err, i)
+		}
+		if got != c.expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", c.expected, got, i)
+		}
+	}
+	for _, income := range []string{"25:00:00", "10:60:00", "ten"} {
+		if _, err := parseClock(income); err == nil {
+			t.Fatalf("Error: expecting error for %q \ngot <nil> \ninstead", income)
+		}
+	}
+}
+
+func TestTradingSchedule_IsTradingDay(t *testing.T) {
+	ts := getTestingSchedule(t)
+	type Case struct {
+		date     time.Time
+		expected bool
+	}
+	cases := []Case{
+		{time.Date(2024, 4, 26, 0, 0, 0, 0, MoscowLocation), true},
+		{time.Date(2024, 4, 27, 0, 0, 0, 0, MoscowLocation), true},
+		{time.Date(2024, 4, 28, 0, 0, 0, 0, MoscowLocation), false},
+		{time.Date(2024, 5, 1, 0, 0, 0, 0, MoscowLocation), false},
+		// it's still April 30 in Moscow
+		{time.Date(2024, 4, 30, 20, 0, 0, 0, time.UTC), true},
+		// it's already May 1 in Moscow
+		{time.Date(2024, 4, 30, 21, 0, 0, 0, time.UTC), false},
+	}
+	for i, c := range cases {
+		if got := ts.IsTradingDay(c.date); got != c.expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", c.expected, got, i)
+		}
+	}
+	if (&TradingSchedule{}).IsTradingDay(time.Now()) {
+		t.Fatalf("Error: expecting an empty schedule has no trading days")
+	}
+}
+
+func TestTradingSchedule_SessionAt(t *testing.T) {
+	ts := getTestingSchedule(t)
+	session, ok := ts.SessionAt(time.Date(2024, 4, 27, 10, 0, 0, 0, MoscowLocation))
+	if !ok {
+		t.Fatalf("Error: expecting an open session \ngot a closed market \ninstead")
+	}
+	expected := SessionHours{
+		Start: time.Date(2024, 4, 27, 9, 50, 0, 0, MoscowLocation),
+		Stop:  time.Date(2024, 4, 27, 19, 0, 0, 0, MoscowLocation),
+	}
+	if !session.Start.Equal(expected.Start) || !session.Stop.Equal(expected.Stop) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, session)
+	}
+	closed := []time.Time{
+		time.Date(2024, 4, 27, 19, 0, 0, 0, MoscowLocation),
+		time.Date(2024, 4, 26, 6, 49, 59, 0, MoscowLocation),
+		time.Date(2024, 4, 28, 12, 0, 0, 0, MoscowLocation),
+		time.Date(2024, 5, 1, 12, 0, 0, 0, MoscowLocation),
+	}
+	for i, c := range closed {
+		if _, ok := ts.SessionAt(c); ok {
+			t.Fatalf("Error: expecting a closed market \ngot an open session \ninstead in %d case", i)
+		}
+	}
+}
+
+func TestTradingSchedule_SessionAtOvernight(t *testing.T) {
+	ts := TradingSchedule{WeekDays: []WeekDaySchedule{
+		{WeekDay: time.Monday, IsWorkDay: true, Start: 19 * time.Hour, Stop: 2 * time.Hour},
+	}}
+	session, ok := ts.SessionAt(time.Date(2024, 4, 30, 1, 0, 0, 0, MoscowLocation))
+	if !ok {
+		t.Fatalf("Error: expecting an open session \ngot a closed market \ninstead")
+	}
+	if got, expected := session.Stop, time.Date(2024, 4, 30, 2, 0, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestTradingSchedule_NextSessionOpen(t *testing.T) {
+	ts := getTestingSchedule(t)
+	type Case struct {
+		now      time.Time
+		expected time.Time
+	}
+	cases := []Case{
+		{time.Date(2024, 4, 26, 5, 0, 0, 0, MoscowLocation), time.Date(2024, 4, 26, 6, 50, 0, 0, MoscowLocation)},
+		{time.Date(2024, 4, 26, 12, 0, 0, 0, MoscowLocation), time.Date(2024, 4, 27, 9, 50, 0, 0, MoscowLocation)},
+		{time.Date(2024, 4, 27, 20, 0, 0, 0, MoscowLocation), time.Date(2024, 4, 29, 6, 50, 0, 0, MoscowLocation)},
+		{time.Date(2024, 4, 30, 23, 55, 0, 0, MoscowLocation), time.Date(2024, 5, 2, 6, 50, 0, 0, MoscowLocation)},
+	}
+	for i, c := range cases {
+		got, ok := ts.NextSessionOpen(c.now)
+		if !ok || !got.Equal(c.expected) {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", c.expected, got, i)
+		}
+	}
+	if _, ok := (&TradingSchedule{}).NextSessionOpen(time.Now()); ok {
+		t.Fatalf("Error: expecting no session of an empty schedule")
+	}
+}
+
+func TestScheduleService_Schedule(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, expected := r.URL.Path, "/engines/stock.json"; got != expected {
+			t.Errorf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+		}
+		byteValue, err := getTestingData("engine_stock.json")
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	ts, err := c.Schedule.Schedule(context.Background(), EngineStock, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(ts.WeekDays), 7; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+}
+
+func TestScheduleService_ScheduleErrors(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.Schedule.Schedule(context.Background(), EngineStock, nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+	if _, err := c.Schedule.Schedule(context.Background(), EngineUndefined, nil); err != ErrBadEngineParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadEngineParameter, err)
+	}
+	var ctx context.Context = nil
+	if _, err := c.Schedule.Schedule(ctx, EngineStock, nil); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
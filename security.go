@@ -38,7 +38,7 @@ type SecuritiesService service
 func (s *SecuritiesService) List(ctx context.Context) (*[]Security, error) {
 	var u string = "securities.json"
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.newEndpointRequest("securities", u)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,240 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path"
+)
+
+// CollectionSecuritiesResponse struct represents a response with securities of a security collection
+type CollectionSecuritiesResponse struct {
+	SecurityGroup string
+	Collection    string
+	Securities    []Security
+	Cursor        *Cursor // it's nil if MoEx ISS API doesn't provide it
+}
+
+const (
+	collectionSecuritiesEndpoint = "securitygroups/{group}/collections/{collection}/securities"
+
+	securityGroupsPartOfPath  = "securitygroups"
+	collectionsPartOfPath     = "collections"
+	collectionSecuritiesUrl   = "securities.json"
+	collectionKeySecurities   = "securities"
+	collectionKeyId           = "id"
+	collectionKeySecId        = "secid"
+	collectionKeyShortName    = "shortname"
+	collectionKeyRegNumber    = "regnumber"
+	collectionKeyName         = "name"
+	collectionKeyIsin         = "isin"
+	collectionKeyIsTraded     = "is_traded"
+	collectionKeyEmitentId    = "emitent_id"
+	collectionKeyEmitentTitle = "emitent_title"
+	collectionKeyEmitentInn   = "emitent_inn"
+	collectionKeyEmitentOkpo  = "emitent_okpo"
+	collectionKeyGosReg       = "gosreg"
+	collectionKeyType         = "type"
+	collectionKeyGroup        = "group"
+	collectionKeyPrimaryBoard = "primary_boardid"
+	collectionKeyMarketPrice  = "marketprice_boardid"
+
+	// collectionSecuritiesMaxPages limits the number of pages requested by CollectionSecuritiesAll
+	collectionSecuritiesMaxPages = 1000
+)
+
+// SecurityGroupsService gets securities of security collections
+// from the MoEx ISS API.
+//
+// MoEx ISS API endpoint:
+// https://iss.moex.com/iss/securitygroups/[securitygroup]/collections/[collection]/securities
+type SecurityGroupsService service
+
+// CollectionSecurities provides a page of securities of the collection(e.g. "stock_shares_one")
+// of the security group(e.g. "stock_shares"), see SecurityGroupsReqOptionsBuilder.Start
+func (s *SecurityGroupsService) CollectionSecurities(ctx context.Context, group string, collection string, opt *SecurityGroupsRequestOptions) (*CollectionSecuritiesResponse, error) {
+	url, err := s.getUrl(group, collection, opt)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.newEndpointRequest(collectionSecuritiesEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = s.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	cr := CollectionSecuritiesResponse{Securities: make([]Security, 0)}
+	err = parseCollectionSecuritiesResponse(b.Bytes(), &cr)
+	if err != nil {
+		return nil, err
+	}
+	cr.SecurityGroup = group
+	cr.Collection = collection
+	return &cr, nil
+}
+
+// CollectionSecuritiesAll provides all the securities of the collection requesting page by page
+// beginning with SecurityGroupsReqOptionsBuilder.Start.
+// It returns ErrTooManyPages if there are more than collectionSecuritiesMaxPages pages.
+func (s *SecurityGroupsService) CollectionSecuritiesAll(ctx context.Context, group string, collection string, opt *SecurityGroupsRequestOptions) (*CollectionSecuritiesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	pageOpt := SecurityGroupsRequestOptions{}
+	if opt != nil {
+		pageOpt = *opt
+	}
+	result := &CollectionSecuritiesResponse{SecurityGroup: group, Collection: collection, Securities: make([]Security, 0)}
+	err := getAllPages(pageOpt.start, collectionSecuritiesMaxPages, func(start uint64) (*Cursor, error) {
+		pageOpt.start = start
+		page, err := s.CollectionSecurities(ctx, group, collection, &pageOpt)
+		if err != nil {
+			return nil, err
+		}
+		result.Securities = append(result.Securities, page.Securities...)
+		if page.Cursor != nil || len(page.Securities) == 0 {
+			return page.Cursor, nil
+		}
+		// MoEx ISS API returns an empty page after the last one if there is no cursor,
+		// so the next page begins after the securities of this one
+		return &Cursor{Index: int64(start), Total: int64(start) + int64(len(page.Securities)) + 1, PageSize: int64(len(page.Securities))}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CollectionSecuritiesOf provides all the securities of the collection returned by IndexService.List,
+// the security group of the collection is looked up in the index.
+// It returns ErrBadSecurityGroupParameter if the index has no security group of the collection.
+func (s *SecurityGroupsService) CollectionSecuritiesOf(ctx context.Context, index *Index, collection SecurityCollection, opt *SecurityGroupsRequestOptions) (*CollectionSecuritiesResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return nil, ErrNilPointer
+	}
+	group, ok := index.SecurityGroupOf(collection)
+	if !ok {
+		return nil, ErrBadSecurityGroupParameter
+	}
+	return s.CollectionSecuritiesAll(ctx, group.Name, collection.Name, opt)
+}
+
+// SecurityGroupOf returns the security group of the security collection
+func (i *Index) SecurityGroupOf(collection SecurityCollection) (SecurityGroup, bool) {
+	for _, sg := range i.SecurityGroups {
+		if sg.Id == collection.SecurityGroupId {
+			return sg, true
+		}
+	}
+	return SecurityGroup{}, false
+}
+
+// getUrl provides an url for a request of securities of the collection
+// opt *SecurityGroupsRequestOptions can be nil, it is safe
+func (s *SecurityGroupsService) getUrl(group string, collection string, opt *SecurityGroupsRequestOptions) (string, error) {
+	if group == "" {
+		return "", ErrBadSecurityGroupParameter
+	}
+	if collection == "" {
+		return "", ErrBadSecurityCollectionParameter
+	}
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
+	url, _ := s.client.BaseURL.Parse(securityGroupsPartOfPath)
+	url.Path = path.Join(url.Path, group, collectionsPartOfPath, collection, collectionSecuritiesUrl)
+	gotURL := addSecurityGroupsRequestOptions(url, opt)
+	return gotURL.String(), nil
+}
+
+func parseCollectionSecuritiesResponse(byteData []byte, cr *CollectionSecuritiesResponse) error {
+	if cr == nil {
+		return ErrNilPointer
+	}
+	return parseBlockWithCursor(byteData, collectionKeySecurities, &cr.Cursor, func(data []byte) error {
+		sec := Security{}
+		if err := parseCollectionSecurity(data, &sec); err != nil {
+			return err
+		}
+		cr.Securities = append(cr.Securities, sec)
+		return nil
+	})
+}
+
+// parseCollectionSecurity parses a security of a collection,
+// only "secid" is required, the other fields are zero values if they are absent
+func parseCollectionSecurity(data []byte, s *Security) (err error) {
+	secId, err := parseStringWithDefaultValueByKey(data, collectionKeySecId, "")
+	if err != nil {
+		return
+	}
+
+	var id int64
+	if hasKey(data, collectionKeyId) {
+		id, err = parseIntWithDefaultValue(data, collectionKeyId)
+		if err != nil {
+			return
+		}
+	}
+
+	var isTraded int64
+	if hasKey(data, collectionKeyIsTraded) {
+		isTraded, err = parseIntWithDefaultValue(data, collectionKeyIsTraded)
+		if err != nil {
+			return
+		}
+	}
+
+	var strValues [13]string
+	strKeys := [13]string{
+		collectionKeyShortName,
+		collectionKeyRegNumber,
+		collectionKeyName,
+		collectionKeyIsin,
+		collectionKeyEmitentId,
+		collectionKeyEmitentTitle,
+		collectionKeyEmitentInn,
+		collectionKeyEmitentOkpo,
+		collectionKeyGosReg,
+		collectionKeyType,
+		collectionKeyGroup,
+		collectionKeyPrimaryBoard,
+		collectionKeyMarketPrice,
+	}
+	for i, key := range strKeys {
+		if !hasKey(data, key) {
+			continue
+		}
+		if strValues[i], err = parseStringWithDefaultValueByKey(data, key, ""); err != nil {
+			return
+		}
+	}
+
+	s.Id = id
+	s.SecId = secId
+	s.ShortName = strValues[0]
+	s.RegNumber = strValues[1]
+	s.Name = strValues[2]
+	s.Isin = strValues[3]
+	s.IsTraded = isTraded == 1
+	s.EmitentId = strValues[4]
+	s.EmitentTitle = strValues[5]
+	s.EmitentInn = strValues[6]
+	s.EmitentOkpo = strValues[7]
+	s.GosReg = strValues[8]
+	s.Type = strValues[9]
+	s.Group = strValues[10]
+	s.PrimaryBoardId = strValues[11]
+	s.MarketPriceBoardId = strValues[12]
+
+	return
+}
@@ -0,0 +1,96 @@
+package moexiss
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// SecurityGroupsRequestOptions contains options which can be used as arguments
+// for building requests to get securities of a security collection.
+// MoEx ISS API endpoint:
+//
+// https://iss.moex.com/iss/securitygroups/[securitygroup]/collections/[collection]/securities
+type SecurityGroupsRequestOptions struct {
+	lang  Language // `lang` query parameter in url.URL
+	start uint64   // `start` query parameter in url.URL
+	limit uint64   // `limit` query parameter in url.URL
+}
+
+// SecurityGroupsReqOptionsBuilder represents a builder of SecurityGroupsRequestOptions struct
+type SecurityGroupsReqOptionsBuilder struct {
+	options *SecurityGroupsRequestOptions
+}
+
+// NewSecurityGroupsReqOptionsBuilder is a constructor of SecurityGroupsReqOptionsBuilder
+func NewSecurityGroupsReqOptionsBuilder() *SecurityGroupsReqOptionsBuilder {
+	return &SecurityGroupsReqOptionsBuilder{options: &SecurityGroupsRequestOptions{}}
+}
+
+// Build builds SecurityGroupsRequestOptions from SecurityGroupsReqOptionsBuilder
+func (b *SecurityGroupsReqOptionsBuilder) Build() *SecurityGroupsRequestOptions {
+	return b.options
+}
+
+// BuildValidated builds SecurityGroupsRequestOptions from SecurityGroupsReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *SecurityGroupsReqOptionsBuilder) BuildValidated() (*SecurityGroupsRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of SecurityGroupsRequestOptions,
+// options can be nil, it is safe
+func (options *SecurityGroupsRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return validateLang("lang", options.lang)
+}
+
+// Lang sets 'lang' parameter to a request
+func (b *SecurityGroupsReqOptionsBuilder) Lang(lang Language) *SecurityGroupsReqOptionsBuilder {
+	b.options.lang = lang
+	return b
+}
+
+// Start sets 'start' parameter to a request
+// Row number (the number of the first row is 0) to begin the result set with.
+// 0 by default
+func (b *SecurityGroupsReqOptionsBuilder) Start(start uint64) *SecurityGroupsReqOptionsBuilder {
+	b.options.start = start
+	return b
+}
+
+// Limit sets 'limit' parameter to a request
+// Number of rows of a page, MoEx ISS API uses its own default if none
+func (b *SecurityGroupsReqOptionsBuilder) Limit(limit uint64) *SecurityGroupsReqOptionsBuilder {
+	b.options.limit = limit
+	return b
+}
+
+// addSecurityGroupsRequestOptions sets parameters into *url.URL
+// from SecurityGroupsRequestOptions struct and returns it back
+func addSecurityGroupsRequestOptions(url *url.URL, options *SecurityGroupsRequestOptions) *url.URL {
+	q := url.Query()
+	q.Set("iss.meta", "off")
+	q.Set("iss.json", "extended")
+	if options == nil {
+		url.RawQuery = q.Encode()
+		return url
+	}
+
+	if options.lang != LangUndefined {
+		q.Set("lang", options.lang.String())
+	}
+	if options.start != 0 {
+		q.Set("start", strconv.FormatUint(options.start, 10))
+	}
+	if options.limit != 0 {
+		q.Set("limit", strconv.FormatUint(options.limit, 10))
+	}
+
+	url.RawQuery = q.Encode()
+	return url
+}
@@ -0,0 +1,83 @@
+package moexiss
+
+import (
+	"testing"
+)
+
+func TestSecurityGroupsReqOptionsBuilder_Build(t *testing.T) {
+	expectStruct := SecurityGroupsRequestOptions{}
+	bld := NewSecurityGroupsReqOptionsBuilder()
+
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` SecurityGroupsRequestOptions \ngot `%v` SecurityGroupsRequestOptions \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsReqOptionsBuilder_Lang(t *testing.T) {
+	expectStruct := SecurityGroupsRequestOptions{lang: LangEn}
+	bld := NewSecurityGroupsReqOptionsBuilder()
+	bld.Lang(LangEn)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsReqOptionsBuilder_Start(t *testing.T) {
+	expectStruct := SecurityGroupsRequestOptions{start: 100}
+	bld := NewSecurityGroupsReqOptionsBuilder()
+	bld.Start(100)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsReqOptionsBuilder_Limit(t *testing.T) {
+	expectStruct := SecurityGroupsRequestOptions{limit: 20}
+	bld := NewSecurityGroupsReqOptionsBuilder()
+	bld.Limit(20)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+}
+
+func TestNewSecurityGroupsRequestOptions(t *testing.T) {
+	expectStruct := SecurityGroupsRequestOptions{
+		lang:  LangRu,
+		start: 10,
+		limit: 5,
+	}
+	bld := NewSecurityGroupsReqOptionsBuilder()
+	bld.Lang(LangRu).Start(10).Limit(5)
+	if got, expected := *bld.Build(), expectStruct; got != expected {
+		t.Fatalf("Error: expecting `%v` \ngot `%v` \ninstead", expected, got)
+	}
+
+}
+
+func TestAddSecurityGroupsRequestOptionsNilOptions(t *testing.T) {
+	var income *SecurityGroupsRequestOptions = nil
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addSecurityGroupsRequestOptions(url, income)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestAddSecurityGroupsRequestOptions(t *testing.T) {
+	var incomeOptions = NewSecurityGroupsReqOptionsBuilder().
+		Lang(LangEn).
+		Start(100).
+		Limit(50).
+		Build()
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL := addSecurityGroupsRequestOptions(url, incomeOptions)
+
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&lang=en&limit=50&start=100`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,275 @@
+package moexiss
+
+import (
+	"context"
+	"fmt"
+	"github.com/buger/jsonparser"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// getCollectionSecuritiesSrv serves the testing collection beginning with 'start' parameter
+// by pages of pageSize securities with a cursor, zero pageSize means all the rest without a cursor
+func getCollectionSecuritiesSrv(t *testing.T, pageSize int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		byteValue, err := getTestingData("collection_securities.json")
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		securities := make([]string, 0)
+		_, _ = jsonparser.ArrayEach(byteValue, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			securities = append(securities, string(value))
+		}, "[1]", "securities")
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		till := len(securities)
+		if pageSize > 0 && start+pageSize < till {
+			till = start + pageSize
+		}
+		page := make([]string, 0)
+		for i := start; i < till; i++ {
+			page = append(page, securities[i])
+		}
+		if pageSize == 0 {
+			_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"securities": [%s]}]`, strings.Join(page, ","))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"securities": [%s], "securities.cursor": [{"INDEX": %d, "TOTAL": %d, "PAGESIZE": %d}]}]`,
+			strings.Join(page, ","), start, len(securities), pageSize)
+	}))
+}
+
+func TestSecurityGroupsGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	got, err := c.SecurityGroups.getUrl("stock_shares", "stock_shares_one", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	expected := `https://iss.moex.com/iss/securitygroups/stock_shares/collections/stock_shares_one/securities.json?iss.json=extended&iss.meta=off`
+	if got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsGetUrlBadParams(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.SecurityGroups.getUrl("", "stock_shares_one", nil); err != ErrBadSecurityGroupParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadSecurityGroupParameter, err)
+	}
+	if _, err := c.SecurityGroups.getUrl("stock_shares", "", nil); err != ErrBadSecurityCollectionParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadSecurityCollectionParameter, err)
+	}
+}
+
+func TestParseCollectionSecurity(t *testing.T) {
+	expectedStruct := Security{
+		Id:                 3498,
+		SecId:              "SBERP",
+		ShortName:          "Сбербанк-п",
+		RegNumber:          "20301481B",
+		Name:               "Сбербанк России ПАО ап",
+		Isin:               "RU0009029557",
+		IsTraded:           true,
+		EmitentId:          "1199",
+		EmitentTitle:       "Публичное акционерное общество \"Сбербанк России\"",
+		EmitentInn:         "7707083893",
+		EmitentOkpo:        "00032537",
+		GosReg:             "20301481B",
+		Type:               "preferred_share",
+		Group:              "stock_shares",
+		PrimaryBoardId:     "TQBR",
+		MarketPriceBoardId: "",
+	}
+	var incomeJSON = `
+{"id": 3498, "secid": "SBERP", "shortname": "Сбербанк-п", "regnumber": "20301481B", "name": "Сбербанк России ПАО ап", "isin": "RU0009029557", "is_traded": 1, "emitent_id": 1199, "emitent_title": "Публичное акционерное общество \"Сбербанк России\"", "emitent_inn": "7707083893", "emitent_okpo": "00032537", "gosreg": "20301481B", "type": "preferred_share", "group": "stock_shares", "primary_boardid": "TQBR", "marketprice_boardid": null}
+`
+	s := Security{}
+	if err := parseCollectionSecurity([]byte(incomeJSON), &s); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := s, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseCollectionSecurityOptionalFields(t *testing.T) {
+	s := Security{}
+	if err := parseCollectionSecurity([]byte(`{"secid": "SBER"}`), &s); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := s, (Security{SecId: "SBER"}); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseCollectionSecurityErrCases(t *testing.T) {
+	if got, expected := parseCollectionSecurity([]byte(`{"id": 1, "shortname": "Сбербанк"}`), &Security{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := parseCollectionSecurity([]byte(`{"secid": "SBER", "is_traded": 1.5}`), &Security{}), jsonparser.MalformedValueError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseCollectionSecuritiesResponseErrCases(t *testing.T) {
+	if got, expected := parseCollectionSecuritiesResponse(nil, nil), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	cr := CollectionSecuritiesResponse{}
+	if got, expected := parseCollectionSecuritiesResponse([]byte(`[{"securities": [[]]}]`), &cr), ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsService_CollectionSecurities(t *testing.T) {
+	srv := getCollectionSecuritiesSrv(t, 0)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.SecurityGroups.CollectionSecurities(context.Background(), "stock_shares", "stock_shares_one", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Securities), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if result.SecurityGroup != "stock_shares" || result.Collection != "stock_shares_one" || result.Cursor != nil {
+		t.Fatalf("Error: unexpected response: \n %v", result)
+	}
+	if got, expected := result.Securities[0].SecId, "AFLT"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsService_CollectionSecuritiesPage(t *testing.T) {
+	srv := getCollectionSecuritiesSrv(t, 2)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	opt := NewSecurityGroupsReqOptionsBuilder().Start(2).Build()
+	result, err := c.SecurityGroups.CollectionSecurities(context.Background(), "stock_shares", "stock_shares_one", opt)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Securities), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := *result.Cursor, (Cursor{Index: 2, Total: 5, PageSize: 2}); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := result.Securities[0].SecId, "LKOH"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsService_CollectionSecuritiesAll(t *testing.T) {
+	srv := getCollectionSecuritiesSrv(t, 2)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.SecurityGroups.CollectionSecuritiesAll(context.Background(), "stock_shares", "stock_shares_one", nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.Securities), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := result.Securities[4].SecId, "SBERP"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsService_CollectionSecuritiesAllStartIgnored(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// the cursor of the first page is returned whatever 'start' is
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"securities": [{"secid": "ABRD"}, {"secid": "AFKS"}], "securities.cursor": [{"INDEX": 0, "TOTAL": 5, "PAGESIZE": 2}]}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.SecurityGroups.CollectionSecuritiesAll(context.Background(), "stock_shares", "stock_shares_one", nil); err != ErrCursorNotAdvanced {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrCursorNotAdvanced, err)
+	}
+	if got, expected := requests, 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v requests\ngot:\n %v requests\ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsService_CollectionSecuritiesAllTooManyPages(t *testing.T) {
+	start := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// every page is new and there is no cursor
+		_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"securities": [{"secid": "S%d"}]}]`, start)
+		start++
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.SecurityGroups.CollectionSecuritiesAll(context.Background(), "stock_shares", "stock_shares_one", nil); err != ErrTooManyPages {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrTooManyPages, err)
+	}
+	if got, expected := start, collectionSecuritiesMaxPages; got != expected {
+		t.Fatalf("Error: expecting: \n %v requests\ngot:\n %v requests\ninstead", expected, got)
+	}
+}
+
+func TestSecurityGroupsService_CollectionSecuritiesOf(t *testing.T) {
+	srv := getCollectionSecuritiesSrv(t, 0)
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	index := &Index{
+		SecurityGroups: []SecurityGroup{
+			{GeneralFields: GeneralFields{Id: 4, Name: "stock_shares", Title: "Акции"}},
+		},
+	}
+	collection := SecurityCollection{GeneralFields: GeneralFields{Id: 3, Name: "stock_shares_one", Title: "Уровень 1"}, SecurityGroupId: 4}
+	result, err := c.SecurityGroups.CollectionSecuritiesOf(context.Background(), index, collection, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := result.SecurityGroup, "stock_shares"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := len(result.Securities), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+
+	collection.SecurityGroupId = 100
+	if _, err = c.SecurityGroups.CollectionSecuritiesOf(context.Background(), index, collection, nil); err != ErrBadSecurityGroupParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadSecurityGroupParameter, err)
+	}
+	if _, err = c.SecurityGroups.CollectionSecuritiesOf(context.Background(), nil, collection, nil); err != ErrNilPointer {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNilPointer, err)
+	}
+}
+
+func TestSecurityGroupsService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	if _, err := c.SecurityGroups.CollectionSecurities(context.Background(), "stock_shares", "stock_shares_one", nil); err != jsonparser.KeyPathNotFoundError {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", jsonparser.KeyPathNotFoundError, err)
+	}
+}
+
+func TestSecurityGroupsNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	if _, err := c.SecurityGroups.CollectionSecurities(ctx, "stock_shares", "stock_shares_one", nil); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
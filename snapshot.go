@@ -0,0 +1,307 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// IndexChangeKind represents a kind of a change of an entry of the index
+type IndexChangeKind uint8
+
+// These constants represent possible values of IndexChangeKind
+const (
+	IndexEntryAdded   IndexChangeKind = 1
+	IndexEntryRemoved IndexChangeKind = 2
+	IndexEntryChanged IndexChangeKind = 3
+)
+
+// String representations of IndexChangeKind values
+func (k IndexChangeKind) String() string {
+	switch k {
+	case IndexEntryAdded:
+		return "added"
+	case IndexEntryRemoved:
+		return "removed"
+	case IndexEntryChanged:
+		return "changed"
+	default:
+		return ""
+	}
+}
+
+// IndexFieldChange represents a changed field of an entry of the index
+type IndexFieldChange struct {
+	Field string // the column of MoEx ISS API, e.g. "is_traded"
+	Old   string
+	New   string
+}
+
+// IndexChange represents an added, a removed or a changed entry of the index
+type IndexChange struct {
+	Table  string // the block of the index, e.g. "boards"
+	Key    string // "boardid" for boards, "interval" for durations and "id" for the others
+	Kind   IndexChangeKind
+	Fields []IndexFieldChange // changed fields, it's empty for added and removed entries
+}
+
+// IndexDiff represents all the changes between two indexes
+type IndexDiff struct {
+	Changes []IndexChange
+}
+
+// IsEmpty reports whether the indexes are the same
+func (d *IndexDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Of returns the changes of the block of the index, e.g. "boards"
+func (d *IndexDiff) Of(table string) []IndexChange {
+	changes := make([]IndexChange, 0)
+	for _, c := range d.Changes {
+		if c.Table == table {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// indexRow represents an entry of the index as a row of MoEx ISS API
+type indexRow struct {
+	key    string
+	values []interface{}
+}
+
+// indexTable describes how a block of the index is written to a snapshot,
+// the columns are the ones of MoEx ISS API stored by the types of the index
+// so a snapshot is parsed by parseIndexResponse
+type indexTable struct {
+	name    string
+	columns []string
+	rows    func(index *Index) []indexRow // rows are sorted by the key
+}
+
+var indexTables = []indexTable{
+	{keyEngines, []string{"id", "name", "title"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.Engines))
+		for i, e := range index.Engines {
+			rows[i] = indexRow{idKey(e.Id), []interface{}{e.Id, e.Name, e.Title}}
+		}
+		return sortIndexRowsById(rows)
+	}},
+	{keyMarkets, []string{"id", "trade_engine_id", "trade_engine_name", "trade_engine_title", "market_name", "market_title", "marketplace"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.Markets))
+		for i, m := range index.Markets {
+			rows[i] = indexRow{idKey(m.Id), []interface{}{m.Id, m.Engine.Id, m.Engine.Name, m.Engine.Title, m.Name, m.Title, m.MarketPlace}}
+		}
+		return sortIndexRowsById(rows)
+	}},
+	{keyBoards, []string{"id", "board_group_id", "engine_id", "market_id", "boardid", "board_title", "is_traded", "has_candles", "is_primary"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.Boards))
+		for i, b := range index.Boards {
+			rows[i] = indexRow{b.BoardId, []interface{}{b.Id, b.BoardGroupId, b.EngineId, b.MarketId, b.BoardId, b.BoardTitle,
+				boolFlag(b.IsTraded), boolFlag(b.HasCandles), boolFlag(b.IsPrimary)}}
+		}
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].key < rows[j].key })
+		return rows
+	}},
+	{keyBoardGroups, []string{"id", "trade_engine_id", "trade_engine_name", "trade_engine_title", "market_id", "market_name", "name", "title", "is_default", "is_traded"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.BoardGroups))
+		for i, bg := range index.BoardGroups {
+			rows[i] = indexRow{idKey(bg.Id), []interface{}{bg.Id, bg.Engine.Id, bg.Engine.Name, bg.Engine.Title, bg.MarketId, bg.MarketName,
+				bg.Name, bg.Title, boolFlag(bg.IsDefault), boolFlag(bg.IsTraded)}}
+		}
+		return sortIndexRowsById(rows)
+	}},
+	{keyDurations, []string{"interval", "duration", "title", "hint"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.Durations))
+		for i, d := range index.Durations {
+			rows[i] = indexRow{idKey(d.Interval), []interface{}{d.Interval, d.Duration, d.Title, d.Hint}}
+		}
+		return sortIndexRowsById(rows)
+	}},
+	{keySecurityTypes, []string{"id", "trade_engine_id", "trade_engine_name", "trade_engine_title", "security_type_name", "security_type_title", "security_group_name"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.SecurityTypes))
+		for i, st := range index.SecurityTypes {
+			rows[i] = indexRow{idKey(st.Id), []interface{}{st.Id, st.Engine.Id, st.Engine.Name, st.Engine.Title, st.Name, st.Title, st.SecurityGroupName}}
+		}
+		return sortIndexRowsById(rows)
+	}},
+	{keySecurityGroups, []string{"id", "name", "title", "is_hidden"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.SecurityGroups))
+		for i, sg := range index.SecurityGroups {
+			rows[i] = indexRow{idKey(sg.Id), []interface{}{sg.Id, sg.Name, sg.Title, boolFlag(sg.IsHidden)}}
+		}
+		return sortIndexRowsById(rows)
+	}},
+	{keySecurityCollections, []string{"id", "name", "title", "security_group_id"}, func(index *Index) []indexRow {
+		rows := make([]indexRow, len(index.SecurityCollections))
+		for i, sc := range index.SecurityCollections {
+			rows[i] = indexRow{idKey(sc.Id), []interface{}{sc.Id, sc.Name, sc.Title, sc.SecurityGroupId}}
+		}
+		return sortIndexRowsById(rows)
+	}},
+}
+
+// WriteIndexSnapshot writes the index in the format of MoEx ISS API,
+// the entries are sorted so snapshots of the same index are byte-to-byte equal
+func WriteIndexSnapshot(w io.Writer, index *Index) error {
+	if index == nil {
+		return ErrNilPointer
+	}
+	bw := bufio.NewWriter(w)
+	bw.WriteString("{")
+	for i, table := range indexTables {
+		if i > 0 {
+			bw.WriteString(",")
+		}
+		columns, err := marshalSnapshotValue(table.columns)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "\n%q: {\n\t\"columns\": %s,\n\t\"data\": [", table.name, columns)
+		for j, row := range table.rows(index) {
+			if j > 0 {
+				bw.WriteString(",")
+			}
+			values, err := marshalSnapshotValue(row.values)
+			if err != nil {
+				return err
+			}
+			bw.WriteString("\n\t\t")
+			bw.Write(values)
+		}
+		bw.WriteString("\n\t]}")
+	}
+	bw.WriteString("\n}\n")
+	return bw.Flush()
+}
+
+// ReadIndexSnapshot reads the index written by WriteIndexSnapshot
+// or the response of MoEx ISS API saved as is
+func ReadIndexSnapshot(r io.Reader) (*Index, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	index := newIndex()
+	if err = parseIndexResponse(b, index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// SaveIndexSnapshot writes the index to the file,
+// the file is replaced only if the whole snapshot is written
+func SaveIndexSnapshot(fileName string, index *Index) error {
+	var b bytes.Buffer
+	if err := WriteIndexSnapshot(&b, index); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(fileName), filepath.Base(fileName)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(b.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fileName)
+}
+
+// LoadIndexSnapshot reads the index from the file written by SaveIndexSnapshot
+func LoadIndexSnapshot(fileName string) (*Index, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadIndexSnapshot(f)
+}
+
+// DiffIndex returns the entries which are added to 'newIndex', removed from 'oldIndex' or changed
+// in the order of the blocks of the index. Added and changed entries of a block go first
+// in the order of their keys and then removed ones do
+func DiffIndex(oldIndex *Index, newIndex *Index) (*IndexDiff, error) {
+	if oldIndex == nil || newIndex == nil {
+		return nil, ErrNilPointer
+	}
+	diff := IndexDiff{Changes: make([]IndexChange, 0)}
+	for _, table := range indexTables {
+		oldRows := table.rows(oldIndex)
+		newRows := table.rows(newIndex)
+		oldByKey := make(map[string]indexRow, len(oldRows))
+		for _, row := range oldRows {
+			oldByKey[row.key] = row
+		}
+		newKeys := make(map[string]bool, len(newRows))
+		for _, row := range newRows {
+			newKeys[row.key] = true
+			oldRow, ok := oldByKey[row.key]
+			if !ok {
+				diff.Changes = append(diff.Changes, IndexChange{Table: table.name, Key: row.key, Kind: IndexEntryAdded})
+				continue
+			}
+			fields := diffIndexRows(table.columns, oldRow, row)
+			if len(fields) > 0 {
+				diff.Changes = append(diff.Changes, IndexChange{Table: table.name, Key: row.key, Kind: IndexEntryChanged, Fields: fields})
+			}
+		}
+		for _, row := range oldRows {
+			if !newKeys[row.key] {
+				diff.Changes = append(diff.Changes, IndexChange{Table: table.name, Key: row.key, Kind: IndexEntryRemoved})
+			}
+		}
+	}
+	return &diff, nil
+}
+
+func diffIndexRows(columns []string, oldRow indexRow, newRow indexRow) []IndexFieldChange {
+	fields := make([]IndexFieldChange, 0)
+	for i, column := range columns {
+		oldValue, newValue := fmt.Sprint(oldRow.values[i]), fmt.Sprint(newRow.values[i])
+		if oldValue != newValue {
+			fields = append(fields, IndexFieldChange{Field: column, Old: oldValue, New: newValue})
+		}
+	}
+	return fields
+}
+
+// marshalSnapshotValue marshals the value without escaping of HTML characters
+func marshalSnapshotValue(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
+
+func sortIndexRowsById(rows []indexRow) []indexRow {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].values[0].(int64) < rows[j].values[0].(int64)
+	})
+	return rows
+}
+
+func idKey(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func boolFlag(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
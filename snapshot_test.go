@@ -0,0 +1,145 @@
+package moexiss
+
+import (
+	"bytes"
+	"github.com/buger/jsonparser"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func getTestingIndex(t *testing.T) *Index {
+	b, err := getTestingData("index.json")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	index := newIndex()
+	if err = parseIndexResponse(b, index); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	return index
+}
+
+func TestIndexSnapshotRoundTrip(t *testing.T) {
+	index := getTestingIndex(t)
+	var b bytes.Buffer
+	if err := WriteIndexSnapshot(&b, index); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	got, err := ReadIndexSnapshot(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(got.Boards) != len(index.Boards) || len(got.SecurityCollections) != len(index.SecurityCollections) {
+		t.Fatalf("Error: expecting %d boards and %d collections \ngot %d and %d \ninstead",
+			len(index.Boards), len(index.SecurityCollections), len(got.Boards), len(got.SecurityCollections))
+	}
+	diff, err := DiffIndex(index, got)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !diff.IsEmpty() {
+		t.Fatalf("Error: expecting no changes after the round trip \ngot %v \ninstead", diff.Changes)
+	}
+
+	// the snapshot of the loaded index is the same
+	var again bytes.Buffer
+	if err = WriteIndexSnapshot(&again, got); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), again.Bytes()) {
+		t.Fatalf("Error: expecting the same snapshot of the loaded index")
+	}
+}
+
+func TestWriteIndexSnapshotColumns(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteIndexSnapshot(&b, getTestingIndex(t)); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	type Case struct {
+		table    string
+		expected []string
+	}
+	cases := []Case{
+		{keyMarkets, []string{"id", "trade_engine_id", "trade_engine_name", "trade_engine_title", "market_name", "market_title", "marketplace"}},
+		{keyBoardGroups, []string{"id", "trade_engine_id", "trade_engine_name", "trade_engine_title", "market_id", "market_name", "name", "title", "is_default", "is_traded"}},
+		{keyDurations, []string{"interval", "duration", "title", "hint"}},
+	}
+	for i, c := range cases {
+		got := make([]string, 0)
+		_, err := jsonparser.ArrayEach(b.Bytes(), func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+			got = append(got, string(value))
+		}, c.table, "columns")
+		if err != nil {
+			t.Fatalf("Error: %v in %d case", err, i)
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d case", c.expected, got, i)
+		}
+	}
+}
+
+func TestWriteIndexSnapshotNilIndex(t *testing.T) {
+	var b bytes.Buffer
+	if got := WriteIndexSnapshot(&b, nil); got != ErrNilPointer {
+		t.Fatalf("Error: expecting error %v \ngot %v \ninstead", ErrNilPointer, got)
+	}
+}
+
+func TestSaveLoadIndexSnapshot(t *testing.T) {
+	index := getTestingIndex(t)
+	fileName := filepath.Join(t.TempDir(), "index.json")
+	if err := SaveIndexSnapshot(fileName, index); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	got, err := LoadIndexSnapshot(fileName)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !reflect.DeepEqual(got.Engines, index.Engines) {
+		t.Fatalf("Error: expecting engines \n%v \ngot \n%v \ninstead", index.Engines, got.Engines)
+	}
+	if _, err = LoadIndexSnapshot(filepath.Join(t.TempDir(), "absent.json")); err == nil {
+		t.Fatalf("Error: expecting an error for an absent file")
+	}
+}
+
+func TestDiffIndex(t *testing.T) {
+	oldIndex := getTestingIndex(t)
+	newIndex := getTestingIndex(t)
+	for i, b := range newIndex.Boards {
+		if b.BoardId == "TQBR" {
+			newIndex.Boards[i].IsTraded = false
+			newIndex.Boards[i].BoardTitle = "T+: Shares"
+		}
+	}
+	newIndex.Markets = newIndex.Markets[1:]
+	newIndex.SecurityCollections = append(newIndex.SecurityCollections,
+		SecurityCollection{GeneralFields: GeneralFields{Id: 1000, Name: "stock_new", Title: "New"}, SecurityGroupId: 4})
+
+	diff, err := DiffIndex(oldIndex, newIndex)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	expected := []IndexChange{
+		{Table: keyMarkets, Key: idKey(oldIndex.Markets[0].Id), Kind: IndexEntryRemoved},
+		{Table: keyBoards, Key: "TQBR", Kind: IndexEntryChanged, Fields: []IndexFieldChange{
+			{Field: "board_title", Old: "Т+: Акции и ДР - безадрес.", New: "T+: Shares"},
+			{Field: "is_traded", Old: "1", New: "0"},
+		}},
+		{Table: keySecurityCollections, Key: "1000", Kind: IndexEntryAdded},
+	}
+	if !reflect.DeepEqual(diff.Changes, expected) {
+		t.Fatalf("Error: expecting changes \n%v \ngot \n%v \ninstead", expected, diff.Changes)
+	}
+	if got := diff.Of(keyBoards); len(got) != 1 || got[0].Kind.String() != "changed" {
+		t.Fatalf("Error: expecting a changed board \ngot %v \ninstead", got)
+	}
+}
+
+func TestDiffIndexNil(t *testing.T) {
+	if _, got := DiffIndex(nil, newIndex()); got != ErrNilPointer {
+		t.Fatalf("Error: expecting error %v \ngot %v \ninstead", ErrNilPointer, got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 const (
 	statsPartsUrl = "secstats.json"
+	statsEndpoint = "engines/{engine}/markets/{market}/secstats"
 
 	secStatKeyTicker           = "SECID"
 	secStatKeyBoardId          = "BOARDID"
@@ -78,13 +79,60 @@ type SecStatResponse struct {
 // MoEx ISS API docs: https://iss.moex.com/iss/reference/823
 type StatsService service
 
-// GetSecStats provides an intermediate day summary
+// GetSecStats provides an intermediate day summary.
+// More than 10 tickers or boards are split into several requests which are run concurrently
+// (see Client.MaxBatchWorkers), their results are merged in the order of the tickers and the boards.
 func (s *StatsService) GetSecStats(ctx context.Context, engine EngineName, market string, opt *StatRequestOptions) (*SecStatResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	chunks := splitStatRequestOptions(opt)
+	if len(chunks) > 1 {
+		return s.getSecStatsChunks(ctx, engine, market, chunks)
+	}
+	return s.getSecStats(ctx, engine, market, chunks[0])
+}
+
+// getSecStatsChunks requests the intermediate day summary for every chunk of options concurrently
+// and merges the results, the first failed request cancels the rest of them
+func (s *StatsService) getSecStatsChunks(ctx context.Context, engine EngineName, market string, chunks []*StatRequestOptions) (*SecStatResponse, error) {
+	if ctx == nil {
+		return nil, ErrNonNilContext
+	}
+	if _, err := s.getUrl(engine, market, nil); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*SecStatResponse, len(chunks))
+	errs := make([]error, len(chunks))
+	s.client.runBatch(ctx, len(chunks), func(ctx context.Context, i int) {
+		results[i], errs[i] = s.getSecStats(ctx, engine, market, chunks[i])
+		if errs[i] != nil {
+			cancel()
+		}
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+
+	if err := firstCauseError(errs); err != nil {
+		return nil, err
+	}
+	ssr := SecStatResponse{Engine: engine, Market: market, SecStats: make([]SecStat, 0)}
+	for _, result := range results {
+		ssr.SecStats = append(ssr.SecStats, result.SecStats...)
+	}
+	return &ssr, nil
+}
+
+// getSecStats requests the intermediate day summary by one request
+func (s *StatsService) getSecStats(ctx context.Context, engine EngineName, market string, opt *StatRequestOptions) (*SecStatResponse, error) {
 	url, err := s.getUrl(engine, market, opt)
 	if err != nil {
 		return nil, err
 	}
-	req, err := s.client.NewRequest("GET", url, nil)
+	req, err := s.client.newEndpointRequest(statsEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -117,13 +165,64 @@ func (s *StatsService) getUrl(engine EngineName, market string, opt *StatRequest
 		return "", ErrBadMarketParameter
 	}
 
+	if err := opt.validate(); err != nil {
+		return "", err
+	}
 	url, _ := s.client.BaseURL.Parse(enginePartOfPath)
 
 	url.Path = path.Join(url.Path, engine.String(), marketsPartOfPath, market, statsPartsUrl)
-	gotURL := addStatRequestOptions(url, opt)
+	gotURL, err := addStatRequestOptions(url, opt)
+	if err != nil {
+		return "", err
+	}
 	return gotURL.String(), nil
 }
 
+// splitStatRequestOptions splits options into options for requests with no more than
+// statParamsLimit tickers and boards each, empty and repeated tickers and boards are skipped.
+// It returns a single chunk if a request is enough.
+func splitStatRequestOptions(opt *StatRequestOptions) []*StatRequestOptions {
+	if opt == nil {
+		return []*StatRequestOptions{opt}
+	}
+	tickerChunks := chunkStatParams(opt.TickerIds)
+	boardChunks := chunkStatParams(opt.BoardId)
+	chunks := make([]*StatRequestOptions, 0, len(tickerChunks)*len(boardChunks))
+	for _, tickers := range tickerChunks {
+		for _, boards := range boardChunks {
+			chunks = append(chunks, &StatRequestOptions{
+				TradingSessionType: opt.TradingSessionType,
+				TickerIds:          tickers,
+				BoardId:            boards,
+			})
+		}
+	}
+	return chunks
+}
+
+// chunkStatParams splits unique non-empty values into chunks of no more than statParamsLimit values,
+// there is a nil chunk for no values
+func chunkStatParams(values []string) [][]string {
+	unique := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		unique = append(unique, v)
+	}
+	if len(unique) == 0 {
+		return [][]string{nil}
+	}
+	chunks := make([][]string, 0, (len(unique)+statParamsLimit-1)/statParamsLimit)
+	for len(unique) > statParamsLimit {
+		chunks = append(chunks, unique[:statParamsLimit])
+		unique = unique[statParamsLimit:]
+	}
+	return append(chunks, unique)
+}
+
 func parseSecStatResponse(byteData []byte, secStatResponse *SecStatResponse) error {
 	var err error
 	if secStatResponse == nil {
@@ -0,0 +1,100 @@
+package moexiss
+
+import "math"
+
+// The analytics of SecStat are derived from its raw fields.
+// MoEx ISS API returns null for absent prices which are parsed as 0, so a zero input means
+// the value is missing. Every method returns false instead of NaN or Inf if an input is missing
+// or the result is undefined, e.g. the range position for equal High and Low.
+
+// basisPointsInOne is the number of basis points in 1
+const basisPointsInOne = 10000
+
+// Change returns the change of the last price against the previous close price (Last - LClosePrice)
+func (s SecStat) Change() (float64, bool) {
+	if s.Last == 0 || s.LClosePrice == 0 {
+		return 0, false
+	}
+	return finiteValue(s.Last - s.LClosePrice)
+}
+
+// ChangePercent returns the change of the last price against the previous close price in percent
+func (s SecStat) ChangePercent() (float64, bool) {
+	change, ok := s.Change()
+	if !ok {
+		return 0, false
+	}
+	return finiteValue(change / s.LClosePrice * 100)
+}
+
+// Spread returns the spread between the best offer and the best bid (LowOffer - HighBid)
+func (s SecStat) Spread() (float64, bool) {
+	if s.HighBid == 0 || s.LowOffer == 0 {
+		return 0, false
+	}
+	return finiteValue(s.LowOffer - s.HighBid)
+}
+
+// SpreadBps returns the spread in basis points of the mid price of the best offer and the best bid
+func (s SecStat) SpreadBps() (float64, bool) {
+	spread, ok := s.Spread()
+	if !ok {
+		return 0, false
+	}
+	mid := (s.LowOffer + s.HighBid) / 2
+	if mid == 0 {
+		return 0, false
+	}
+	return finiteValue(spread / mid * basisPointsInOne)
+}
+
+// VwapDeviation returns the deviation of the last price from the weighted average price (Last - WaPrice)
+func (s SecStat) VwapDeviation() (float64, bool) {
+	if s.Last == 0 || s.WaPrice == 0 {
+		return 0, false
+	}
+	return finiteValue(s.Last - s.WaPrice)
+}
+
+// VwapDeviationPercent returns the deviation of the last price from the weighted average price in percent
+func (s SecStat) VwapDeviationPercent() (float64, bool) {
+	deviation, ok := s.VwapDeviation()
+	if !ok {
+		return 0, false
+	}
+	return finiteValue(deviation / s.WaPrice * 100)
+}
+
+// RangePosition returns the position of the last price in the day range,
+// 0 is the low and 1 is the high ((Last - Low) / (High - Low)).
+// It returns false if the range is empty, i.e. High equals Low
+func (s SecStat) RangePosition() (float64, bool) {
+	if s.Last == 0 || s.Low == 0 || s.High == 0 || s.High <= s.Low {
+		return 0, false
+	}
+	return finiteValue((s.Last - s.Low) / (s.High - s.Low))
+}
+
+// AvgTradeValue returns the average value of a trade (ValToday / NumTrades)
+func (s SecStat) AvgTradeValue() (float64, bool) {
+	if s.NumTrades <= 0 || s.ValToday == 0 {
+		return 0, false
+	}
+	return finiteValue(float64(s.ValToday) / float64(s.NumTrades))
+}
+
+// AvgTradeVolume returns the average volume of a trade (VolToday / NumTrades)
+func (s SecStat) AvgTradeVolume() (float64, bool) {
+	if s.NumTrades <= 0 || s.VolToday == 0 {
+		return 0, false
+	}
+	return finiteValue(float64(s.VolToday) / float64(s.NumTrades))
+}
+
+// finiteValue returns false for NaN and Inf
+func finiteValue(value float64) (float64, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,69 @@
+package moexiss
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSecStatAnalytics(t *testing.T) {
+	s := SecStat{
+		Last:        102,
+		LClosePrice: 100,
+		HighBid:     101.9,
+		LowOffer:    102.1,
+		WaPrice:     101.5,
+		Low:         98,
+		High:        103,
+		VolToday:    5000,
+		ValToday:    510000,
+		NumTrades:   50,
+	}
+	var cases = []struct {
+		name     string
+		fn       func() (float64, bool)
+		expected float64
+	}{
+		{"Change", s.Change, 2},
+		{"ChangePercent", s.ChangePercent, 2},
+		{"Spread", s.Spread, 0.2},
+		{"SpreadBps", s.SpreadBps, 0.2 / 102 * 10000},
+		{"VwapDeviation", s.VwapDeviation, 0.5},
+		{"VwapDeviationPercent", s.VwapDeviationPercent, 0.5 / 101.5 * 100},
+		{"RangePosition", s.RangePosition, 0.8},
+		{"AvgTradeValue", s.AvgTradeValue, 10200},
+		{"AvgTradeVolume", s.AvgTradeVolume, 100},
+	}
+	for _, c := range cases {
+		got, ok := c.fn()
+		if !ok || math.Abs(got-c.expected) > 1e-9 {
+			t.Fatalf("Error: expecting %s %v \ngot %v, %v \ninstead", c.name, c.expected, got, ok)
+		}
+	}
+}
+
+func TestSecStatAnalyticsMissingInputs(t *testing.T) {
+	var cases = []struct {
+		name string
+		s    SecStat
+		fn   func(s SecStat) (float64, bool)
+	}{
+		{"Change without Last", SecStat{LClosePrice: 100}, SecStat.Change},
+		{"ChangePercent without LClosePrice", SecStat{Last: 100}, SecStat.ChangePercent},
+		{"Spread without HighBid", SecStat{LowOffer: 100}, SecStat.Spread},
+		{"SpreadBps without LowOffer", SecStat{HighBid: 100}, SecStat.SpreadBps},
+		{"SpreadBps with zero mid price", SecStat{HighBid: -1, LowOffer: 1}, SecStat.SpreadBps},
+		{"VwapDeviation without WaPrice", SecStat{Last: 100}, SecStat.VwapDeviation},
+		{"VwapDeviationPercent without Last", SecStat{WaPrice: 100}, SecStat.VwapDeviationPercent},
+		{"RangePosition of an empty range", SecStat{Last: 100, Low: 100, High: 100}, SecStat.RangePosition},
+		{"RangePosition without High", SecStat{Last: 100, Low: 100}, SecStat.RangePosition},
+		{"AvgTradeValue without trades", SecStat{ValToday: 100}, SecStat.AvgTradeValue},
+		{"AvgTradeVolume without trades", SecStat{VolToday: 100}, SecStat.AvgTradeVolume},
+		{"Change of huge values", SecStat{Last: math.MaxFloat64, LClosePrice: -math.MaxFloat64}, SecStat.Change},
+		{"ChangePercent of huge values", SecStat{Last: math.MaxFloat64, LClosePrice: -math.MaxFloat64}, SecStat.ChangePercent},
+	}
+	for _, c := range cases {
+		if got, ok := c.fn(c.s); ok || got != 0 {
+			t.Fatalf("Error: expecting no value for %s \ngot %v, %v \ninstead", c.name, got, ok)
+		}
+	}
+}
@@ -37,6 +37,15 @@ func getTradingSession(value string) TradingSession {
 	}
 }
 
+const (
+	statParamTradingSession = "tradingsession"
+	statParamSecurities     = "securities"
+	statParamBoardId        = "boardid"
+
+	// statParamsLimit is the max number of tickers or boards of a request to MoEx ISS API
+	statParamsLimit = 10
+)
+
 // StatRequestOptions contains options which can be used as arguments
 // for building requests to get intermediate day summary.
 // MoEx ISS API docs: https://iss.moex.com/iss/reference/823
@@ -70,7 +79,7 @@ func (b *StatReqOptionsBuilder) TypeTradingSession(ts TradingSession) *StatReqOp
 
 // AddTicker adds a ticker to a request
 // It allows to show data only for the required tickers.
-// No more than 10 tickers.
+// StatsService.GetSecStats splits more than 10 tickers into several requests.
 func (b *StatReqOptionsBuilder) AddTicker(ticker string) *StatReqOptionsBuilder {
 	b.options.TickerIds = append(b.options.TickerIds, ticker)
 	return b
@@ -78,21 +87,54 @@ func (b *StatReqOptionsBuilder) AddTicker(ticker string) *StatReqOptionsBuilder
 
 // AddBoard adds a board to a request
 // Filter the output by trading mode.
-// No more than 10 boards.
+// StatsService.GetSecStats splits more than 10 boards into several requests.
 func (b *StatReqOptionsBuilder) AddBoard(boardId string) *StatReqOptionsBuilder {
 	b.options.BoardId = append(b.options.BoardId, boardId)
 	return b
 }
 
+// BuildValidated builds StatRequestOptions from StatReqOptionsBuilder
+// and returns *ValidationError if an option is invalid
+func (b *StatReqOptionsBuilder) BuildValidated() (*StatRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+// validate checks parameters of StatRequestOptions,
+// options can be nil, it is safe
+func (options *StatRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	if options.TradingSessionType > TradingSessionTotal {
+		return &ValidationError{Option: statParamTradingSession, Value: options.TradingSessionType.String(), Reason: "unknown trading session"}
+	}
+	for _, ticker := range options.TickerIds {
+		if ticker == "" {
+			return &ValidationError{Option: statParamSecurities, Value: ticker, Reason: "empty ticker"}
+		}
+	}
+	for _, board := range options.BoardId {
+		if board == "" {
+			return &ValidationError{Option: statParamBoardId, Value: board, Reason: "empty board"}
+		}
+	}
+	return nil
+}
+
 // addStatRequestOptions sets parameters into *url.URL
-// from StatRequestOptions struct and returns it back
-func addStatRequestOptions(url *url.URL, options *StatRequestOptions) *url.URL {
+// from StatRequestOptions struct and returns it back.
+// It returns *ValidationError if there are more than statParamsLimit tickers or boards,
+// see splitStatRequestOptions
+func addStatRequestOptions(url *url.URL, options *StatRequestOptions) (*url.URL, error) {
 	q := url.Query()
 	q.Set("iss.meta", "off")
 	q.Set("iss.json", "extended")
 	if options == nil {
 		url.RawQuery = q.Encode()
-		return url
+		return url, nil
 	}
 
 	trType := options.TradingSessionType
@@ -100,38 +142,36 @@ func addStatRequestOptions(url *url.URL, options *StatRequestOptions) *url.URL {
 		(trType == TradingSessionMain ||
 			trType == TradingSessionAdditional ||
 			trType == TradingSessionTotal) {
-		q.Set("tradingsession", trType.String())
+		q.Set(statParamTradingSession, trType.String())
 	}
-	limit := 10
-	if len(options.TickerIds) > 0 {
-		addArrayParams(&q, "securities", options.TickerIds, limit)
+	if err := addArrayParams(&q, statParamSecurities, options.TickerIds, statParamsLimit); err != nil {
+		return nil, err
 	}
-	if len(options.BoardId) > 0 {
-		addArrayParams(&q, "boardid", options.BoardId, limit)
+	if err := addArrayParams(&q, statParamBoardId, options.BoardId, statParamsLimit); err != nil {
+		return nil, err
 	}
 	url.RawQuery = q.Encode()
-	return url
+	return url, nil
 }
 
-func addArrayParams(q *url.Values, key string, values []string, limit int) {
-	bld := strings.Builder{}
-	counter := 0
+// addArrayParams sets comma separated values to the key, empty and repeated values are skipped.
+// It returns *ValidationError if there are more than 'limit' values
+func addArrayParams(q *url.Values, key string, values []string, limit int) error {
+	unique := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
 	for _, value := range values {
-		if value == "" {
+		if value == "" || seen[value] {
 			continue
 		}
-		counter++
-		if counter > limit {
-			break
-		}
-		if counter > 1 {
-			bld.WriteString(",")
-		}
-		bld.WriteString(value)
+		seen[value] = true
+		unique = append(unique, value)
+	}
+	if len(unique) > limit {
+		return &ValidationError{Option: key, Value: strconv.Itoa(len(unique)), Reason: "more than " + strconv.Itoa(limit) + " values"}
 	}
-	if bld.Len() == 0 {
-		return
+	if len(unique) == 0 {
+		return nil
 	}
-	str := bld.String()
-	q.Set(key, str)
+	q.Set(key, strings.Join(unique, ","))
+	return nil
 }
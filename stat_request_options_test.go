@@ -1,6 +1,10 @@
 package moexiss
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
 
 func TestTradingSession_String(t *testing.T) {
 	if got, expected := TradingSessionUndefined.String(), "0"; got != expected {
@@ -162,7 +166,10 @@ func TestAddStatRequestOptions(t *testing.T) {
 		Build()
 	c := NewClient(nil)
 	url, _ := c.BaseURL.Parse("test.json")
-	gotURL := addStatRequestOptions(url, incomeOptions)
+	gotURL, err := addStatRequestOptions(url, incomeOptions)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
 
 	expected := `https://iss.moex.com/iss/test.json?boardid=TQBR%2CSMAL&iss.json=extended&iss.meta=off&securities=SBERP%2CGAZP%2CDSKY&tradingsession=1`
 	if got := gotURL.String(); got != expected {
@@ -188,9 +195,26 @@ func TestAddStatRequestOptionsMore10(t *testing.T) {
 		Build()
 	c := NewClient(nil)
 	url, _ := c.BaseURL.Parse("test.json")
-	gotURL := addStatRequestOptions(url, incomeOptions)
+	_, err := addStatRequestOptions(url, incomeOptions)
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Option != statParamBoardId {
+		t.Fatalf("Error: expecting *ValidationError of %s option \ngot %v \ninstead", statParamBoardId, err)
+	}
+}
+
+func TestAddStatRequestOptionsRepeated(t *testing.T) {
+	bld := NewStatReqOptionsBuilder().AddTicker("T00")
+	for i := 0; i < 10; i++ {
+		bld.AddTicker(fmt.Sprintf("T%02d", i))
+	}
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("test.json")
+	gotURL, err := addStatRequestOptions(url, bld.Build())
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
 
-	expected := `https://iss.moex.com/iss/test.json?boardid=0000%2C0001%2C0002%2C0003%2C0004%2C0005%2C0006%2C0007%2C0008%2C0009&iss.json=extended&iss.meta=off&tradingsession=1`
+	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off&securities=T00%2CT01%2CT02%2CT03%2CT04%2CT05%2CT06%2CT07%2CT08%2CT09`
 	if got := gotURL.String(); got != expected {
 		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
 	}
@@ -199,10 +223,41 @@ func TestAddStatRequestOptionsMore10(t *testing.T) {
 func TestAddStatRequestOptionsNil(t *testing.T) {
 	c := NewClient(nil)
 	url, _ := c.BaseURL.Parse("test.json")
-	gotURL := addStatRequestOptions(url, nil)
+	gotURL, _ := addStatRequestOptions(url, nil)
 
 	expected := `https://iss.moex.com/iss/test.json?iss.json=extended&iss.meta=off`
 	if got := gotURL.String(); got != expected {
 		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
 	}
 }
+
+func TestStatReqOptionsBuilder_BuildValidated(t *testing.T) {
+	opt, err := NewStatReqOptionsBuilder().AddTicker("GAZP").AddBoard("TQBR").BuildValidated()
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(opt.TickerIds), 1; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+
+	checkBuilderCases(t, []builderCase{
+		{func() (bool, error) {
+			opt, err := NewStatReqOptionsBuilder().AddTicker("GAZP").AddTicker("").BuildValidated()
+			return opt != nil, err
+		}, statParamSecurities},
+		{func() (bool, error) {
+			opt, err := NewStatReqOptionsBuilder().AddBoard("").BuildValidated()
+			return opt != nil, err
+		}, statParamBoardId},
+		{func() (bool, error) {
+			opt, err := NewStatReqOptionsBuilder().TypeTradingSession(TradingSession(4)).BuildValidated()
+			return opt != nil, err
+		}, statParamTradingSession},
+	})
+}
+
+func TestStatRequestOptionsValidateNil(t *testing.T) {
+	if err := (*StatRequestOptions)(nil).validate(); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+}
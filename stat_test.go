@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -370,3 +371,118 @@ func TestStatsServiceNilContextError(t *testing.T) {
 		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
 	}
 }
+
+func TestChunkStatParams(t *testing.T) {
+	values := make([]string, 0)
+	for i := 0; i < 23; i++ {
+		values = append(values, fmt.Sprintf("T%02d", i))
+	}
+	values = append(values, "", "T00")
+	chunks := chunkStatParams(values)
+	if got, expected := len(chunks), 3; got != expected {
+		t.Fatalf("Error: expecting: \n %v chunks\ngot:\n %v chunks\ninstead", expected, got)
+	}
+	if got, expected := fmt.Sprint(chunks[2]), "[T20 T21 T22]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got := chunkStatParams([]string{""}); len(got) != 1 || got[0] != nil {
+		t.Fatalf("Error: expecting a nil chunk \ngot %v \ninstead", got)
+	}
+}
+
+func TestSplitStatRequestOptions(t *testing.T) {
+	if got := splitStatRequestOptions(nil); len(got) != 1 || got[0] != nil {
+		t.Fatalf("Error: expecting <nil> options \ngot %v \ninstead", got)
+	}
+	// repeated tickers don't count against the limit of a request
+	small := NewStatReqOptionsBuilder().AddTicker("T00")
+	for i := 0; i < 10; i++ {
+		small.AddTicker(fmt.Sprintf("T%02d", i))
+	}
+	got := splitStatRequestOptions(small.Build())
+	if len(got) != 1 {
+		t.Fatalf("Error: expecting a single chunk \ngot %v \ninstead", got)
+	}
+	if got, expected := fmt.Sprint(got[0].TickerIds), "[T00 T01 T02 T03 T04 T05 T06 T07 T08 T09]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	bld := NewStatReqOptionsBuilder().TypeTradingSession(TradingSessionMain)
+	for i := 0; i < 12; i++ {
+		bld.AddTicker(fmt.Sprintf("T%02d", i))
+		bld.AddBoard(fmt.Sprintf("B%02d", i))
+	}
+	chunks := splitStatRequestOptions(bld.Build())
+	if got, expected := len(chunks), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v chunks\ngot:\n %v chunks\ninstead", expected, got)
+	}
+	last := chunks[3]
+	if got, expected := fmt.Sprint(last.TickerIds, last.BoardId, last.TradingSessionType), "[T10 T11] [B10 B11] 1"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestStatsService_GetSecStatsChunks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tickers := strings.Split(r.URL.Query().Get("securities"), ",")
+		if len(tickers) > 10 {
+			t.Errorf("Error: expecting no more than 10 tickers \ngot %d \ninstead", len(tickers))
+		}
+		rows := make([]string, 0, len(tickers))
+		for _, ticker := range tickers {
+			rows = append(rows, fmt.Sprintf(`{"SECID": "%s", "BOARDID": "TQBR", "TRADINGSESSION": "1", "TIME": "18:40:00", "PRICEMINUSPREVWAPRICE": 0, "VOLTODAY": 1, "VALTODAY": 1, "HIGHBID": 0, "LOWOFFER": 0, "LASTOFFER": 0, "LASTBID": 0, "OPEN": 1, "LOW": 1, "HIGH": 1, "LAST": 1, "LCLOSEPRICE": 1, "NUMTRADES": 1, "WAPRICE": 1, "ADMITTEDQUOTE": 1, "MARKETPRICE2": 1, "LCURRENTPRICE": 1, "CLOSINGAUCTIONPRICE": 1}`, ticker))
+		}
+		_, _ = fmt.Fprintf(w, `[{"charsetinfo": {"name": "utf-8"}}, {"secstats": [%s]}]`, strings.Join(rows, ","))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	bld := NewStatReqOptionsBuilder()
+	for i := 0; i < 25; i++ {
+		bld.AddTicker(fmt.Sprintf("T%02d", i))
+	}
+	result, err := c.Stats.GetSecStats(context.Background(), EngineStock, "shares", bld.Build())
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result.SecStats), 25; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	for i, ss := range result.SecStats {
+		if got, expected := ss.Ticker, fmt.Sprintf("T%02d", i); got != expected {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+		}
+	}
+	if result.Engine != EngineStock || result.Market != "shares" {
+		t.Fatalf("Error: expecting stock/shares \ngot %v/%v \ninstead", result.Engine, result.Market)
+	}
+}
+
+func TestStatsService_GetSecStatsChunksError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Query().Get("securities"), "T10") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"secstats": []}]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	bld := NewStatReqOptionsBuilder()
+	for i := 0; i < 25; i++ {
+		bld.AddTicker(fmt.Sprintf("T%02d", i))
+	}
+	_, err := c.Stats.GetSecStats(context.Background(), EngineStock, "shares", bld.Build())
+	if got, expected := err, "status:[500] 500 Internal Server Error"; got == nil || got.Error() != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+	if _, err = c.Stats.GetSecStats(context.Background(), EngineStock, "", bld.Build()); err != ErrBadMarketParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadMarketParameter, err)
+	}
+	var ctx context.Context = nil
+	if _, err = c.Stats.GetSecStats(ctx, EngineStock, "shares", bld.Build()); err != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, err)
+	}
+}
@@ -0,0 +1,356 @@
+package moexiss
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultWatcherInterval is the interval of polls of a Watcher by default
+	defaultWatcherInterval = 5 * time.Second
+	// defaultWatcherMaxBackoff limits the delay of polls after errors by default
+	defaultWatcherMaxBackoff = 5 * time.Minute
+)
+
+// SecStatChange represents a new or a changed row of the intermediate day summary
+type SecStatChange struct {
+	SecStat SecStat
+	IsNew   bool     // the row is sent to the subscriber for the first time
+	Fields  []string // MoEx ISS API keys of the changed fields, e.g. "LAST", it's nil for a new row
+}
+
+// Watcher polls the intermediate day summary and sends the changed rows to its subscribers.
+// All the subscribers share one poll which runs while there is at least one subscriber.
+// Change Interval, MaxBackoff, Schedule and OnError before the first Subscribe only.
+type Watcher struct {
+	Interval   time.Duration    // the interval of polls, defaultWatcherInterval is used if it's 0
+	MaxBackoff time.Duration    // the limit of the delay after errors, defaultWatcherMaxBackoff is used if it's 0
+	Schedule   *TradingSchedule // polls are paused outside of trading sessions if it isn't nil
+	OnError    func(err error)  // it's called for every failed poll if it isn't nil
+
+	stats  *StatsService
+	engine EngineName
+	market string
+	opt    *StatRequestOptions
+
+	mu          sync.Mutex
+	subscribers []*watcherSubscriber
+	running     bool
+	pollCtx     context.Context // the context of requests of the poll
+	cancelPoll  context.CancelFunc
+	wake        chan struct{}
+}
+
+// watcherSubscriber represents a subscriber of a Watcher,
+// its channel is closed by its sender only
+type watcherSubscriber struct {
+	ctx    context.Context
+	ch     chan SecStatChange
+	primed bool // all the rows are queued for the subscriber, it's used by the poll only
+
+	mu      sync.Mutex
+	pending []SecStatChange // the changes which aren't taken by the sender yet
+	index   map[string]int  // the indexes of the pending changes by secStatKey
+	ready   chan struct{}   // it's signaled when there are pending changes
+}
+
+// NewWatcher returns a Watcher of the intermediate day summary of the engine and the market,
+// see StatsService.GetSecStats for the options
+func (s *StatsService) NewWatcher(engine EngineName, market string, opt *StatRequestOptions) *Watcher {
+	return &Watcher{
+		stats:  s,
+		engine: engine,
+		market: market,
+		opt:    opt,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Subscribe returns a channel of changed rows which is closed when ctx is done.
+// A new subscriber gets all the current rows as new ones with the next poll and then only the changed ones.
+// Every subscriber has its own sender, so a slow subscriber doesn't delay the others:
+// the pending changes of a row are coalesced into the latest one until the subscriber reads them.
+func (w *Watcher) Subscribe(ctx context.Context) (<-chan SecStatChange, error) {
+	if ctx == nil {
+		return nil, ErrNonNilContext
+	}
+	if _, err := w.stats.getUrl(w.engine, w.market, w.opt); err != nil {
+		return nil, err
+	}
+	sub := &watcherSubscriber{
+		ctx:   ctx,
+		ch:    make(chan SecStatChange),
+		index: make(map[string]int),
+		ready: make(chan struct{}, 1),
+	}
+	go sub.deliver()
+
+	w.mu.Lock()
+	w.subscribers = append(w.subscribers, sub)
+	if !w.running || w.pollCtx.Err() != nil {
+		// the requests of the poll are canceled when all the previous subscribers are done
+		w.pollCtx, w.cancelPoll = context.WithCancel(context.Background())
+	}
+	if !w.running {
+		w.running = true
+		go w.run()
+	}
+	w.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.mu.Lock()
+		if w.allDone() {
+			// a request of the poll isn't needed anymore
+			w.cancelPoll()
+		}
+		w.mu.Unlock()
+		select {
+		case w.wake <- struct{}{}:
+		default:
+		}
+	}()
+	return sub.ch, nil
+}
+
+// run polls while there is at least one subscriber
+func (w *Watcher) run() {
+	last := make(map[string]SecStat)
+	failures := 0
+	next := time.Now()
+	for {
+		subscribers, ctx, ok := w.prune()
+		if !ok {
+			return
+		}
+		if delay := time.Until(next); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-w.wake:
+				timer.Stop()
+				continue
+			}
+		}
+		now := time.Now()
+		if open, ok := w.nextOpen(now); ok {
+			next = open
+			continue
+		}
+		resp, err := w.stats.GetSecStats(ctx, w.engine, w.market, w.opt)
+		if err != nil {
+			if ctx.Err() != nil {
+				// all the subscribers are done
+				continue
+			}
+			failures++
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+			next = now.Add(w.backoff(failures))
+			continue
+		}
+		failures = 0
+		next = now.Add(w.interval())
+
+		current := make(map[string]SecStat, len(resp.SecStats))
+		changes := make([]SecStatChange, 0)
+		all := make([]SecStatChange, 0, len(resp.SecStats))
+		for _, s := range resp.SecStats {
+			key := secStatKey(s)
+			current[key] = s
+			all = append(all, SecStatChange{SecStat: s, IsNew: true})
+			old, found := last[key]
+			if !found {
+				changes = append(changes, SecStatChange{SecStat: s, IsNew: true})
+				continue
+			}
+			if fields := changedSecStatFields(old, s); len(fields) > 0 {
+				changes = append(changes, SecStatChange{SecStat: s, Fields: fields})
+			}
+		}
+		last = current
+		for _, sub := range subscribers {
+			if sub.primed {
+				sub.queue(changes)
+				continue
+			}
+			sub.queue(all)
+			sub.primed = true
+		}
+	}
+}
+
+// prune removes the done subscribers and returns the rest of them
+// with the context of requests, it returns false and stops the poll if there are no subscribers
+func (w *Watcher) prune() ([]*watcherSubscriber, context.Context, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	active := w.subscribers[:0]
+	for _, sub := range w.subscribers {
+		if sub.ctx.Err() != nil {
+			continue
+		}
+		active = append(active, sub)
+	}
+	w.subscribers = active
+	if len(active) == 0 {
+		w.running = false
+		w.cancelPoll()
+		return nil, nil, false
+	}
+	return append([]*watcherSubscriber{}, active...), w.pollCtx, true
+}
+
+// allDone reports whether all the subscribers are done, w.mu must be held
+func (w *Watcher) allDone() bool {
+	for _, sub := range w.subscribers {
+		if sub.ctx.Err() == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// nextOpen returns the start of the next trading session if the market is closed at 'now'
+// according to the schedule, the market is treated as open without the schedule
+// or if the schedule has no next session
+func (w *Watcher) nextOpen(now time.Time) (time.Time, bool) {
+	if w.Schedule == nil {
+		return time.Time{}, false
+	}
+	if _, ok := w.Schedule.SessionAt(now); ok {
+		return time.Time{}, false
+	}
+	return w.Schedule.NextSessionOpen(now)
+}
+
+func (w *Watcher) interval() time.Duration {
+	if w.Interval <= 0 {
+		return defaultWatcherInterval
+	}
+	return w.Interval
+}
+
+// backoff returns the delay after the failed polls in a row,
+// the interval is doubled for every failure up to MaxBackoff
+func (w *Watcher) backoff(failures int) time.Duration {
+	maxBackoff := w.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultWatcherMaxBackoff
+	}
+	delay := w.interval()
+	for i := 0; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+// queue adds the changes to the pending ones of the subscriber without waiting for it,
+// a pending change of the same row is replaced with the merged one
+func (sub *watcherSubscriber) queue(changes []SecStatChange) {
+	if len(changes) == 0 {
+		return
+	}
+	sub.mu.Lock()
+	for _, c := range changes {
+		key := secStatKey(c.SecStat)
+		if i, found := sub.index[key]; found {
+			sub.pending[i] = mergeSecStatChanges(sub.pending[i], c)
+			continue
+		}
+		sub.index[key] = len(sub.pending)
+		sub.pending = append(sub.pending, c)
+	}
+	sub.mu.Unlock()
+	select {
+	case sub.ready <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the pending changes to the subscriber until it's done,
+// then it closes the channel of the subscriber
+func (sub *watcherSubscriber) deliver() {
+	defer close(sub.ch)
+	for {
+		select {
+		case <-sub.ready:
+		case <-sub.ctx.Done():
+			return
+		}
+		sub.mu.Lock()
+		changes := sub.pending
+		sub.pending = nil
+		sub.index = make(map[string]int)
+		sub.mu.Unlock()
+		for _, c := range changes {
+			select {
+			case sub.ch <- c:
+			case <-sub.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// mergeSecStatChanges returns the change of the row which isn't received yet followed by the next change,
+// it's a new row if one of them is new, otherwise the changed fields of both
+func mergeSecStatChanges(prev SecStatChange, cur SecStatChange) SecStatChange {
+	if prev.IsNew || cur.IsNew {
+		return SecStatChange{SecStat: cur.SecStat, IsNew: true}
+	}
+	fields := append([]string{}, prev.Fields...)
+	for _, f := range cur.Fields {
+		found := false
+		for _, pf := range prev.Fields {
+			if pf == f {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fields = append(fields, f)
+		}
+	}
+	return SecStatChange{SecStat: cur.SecStat, Fields: fields}
+}
+
+// secStatKey returns the key of a row of the intermediate day summary
+func secStatKey(s SecStat) string {
+	return s.Ticker + "/" + s.BoardId + "/" + s.TrSession.String()
+}
+
+// changedSecStatFields returns MoEx ISS API keys of the fields which differ
+func changedSecStatFields(prev SecStat, cur SecStat) []string {
+	var fields []string
+	add := func(changed bool, key string) {
+		if changed {
+			fields = append(fields, key)
+		}
+	}
+	add(prev.Time != cur.Time, secStatKeyTime)
+	add(prev.PriceMinusPrevPr != cur.PriceMinusPrevPr, secStatKeyPriceMinusPrevPr)
+	add(prev.VolToday != cur.VolToday, secStatKeyVolToday)
+	add(prev.ValToday != cur.ValToday, secStatKeyValToday)
+	add(prev.HighBid != cur.HighBid, secStatKeyHighBid)
+	add(prev.LowOffer != cur.LowOffer, secStatKeyLowOffer)
+	add(prev.LastOffer != cur.LastOffer, secStatKeyLastOffer)
+	add(prev.LastBid != cur.LastBid, secStatKeyLastBid)
+	add(prev.Open != cur.Open, secStatKeyOpen)
+	add(prev.Low != cur.Low, secStatKeyLow)
+	add(prev.High != cur.High, secStatKeyHigh)
+	add(prev.Last != cur.Last, secStatKeyLast)
+	add(prev.LClosePrice != cur.LClosePrice, secStatKeyLClosePrice)
+	add(prev.NumTrades != cur.NumTrades, secStatKeyNumTrades)
+	add(prev.WaPrice != cur.WaPrice, secStatKeyWaPrice)
+	add(prev.AdmittedQuote != cur.AdmittedQuote, secStatKeyAdmittedQuote)
+	add(prev.MarketPrice != cur.MarketPrice, secStatKeyMarketPrice)
+	add(prev.LCurrentPrice != cur.LCurrentPrice, secStatKeyLCurrentPrice)
+	add(prev.ClosingAucPrice != cur.ClosingAucPrice, secStatKeyClosingAucPrice)
+	return fields
+}
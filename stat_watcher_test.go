@@ -0,0 +1,235 @@
+package moexiss
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const watcherTestingRows = 6
+
+// getWatcherTestingSrv emulates an external server,
+// the LAST price of DSKY on TQBR changes since the second request
+func getWatcherTestingSrv(release <-chan struct{}) (*Client, *int32, func()) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if release != nil {
+			<-release
+		}
+		b, _ := getTestingData("secstats.json")
+		if n > 1 {
+			b = bytes.Replace(b, []byte(`"LAST": 92.54`), []byte(`"LAST": 93`), 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}))
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	return c, &requests, srv.Close
+}
+
+func receiveChange(t *testing.T, ch <-chan SecStatChange) SecStatChange {
+	select {
+	case c, ok := <-ch:
+		if !ok {
+			t.Fatalf("Error: expecting a change \ngot the closed channel \ninstead")
+		}
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Error: expecting a change \ngot a timeout \ninstead")
+	}
+	return SecStatChange{}
+}
+
+func TestWatcher_SubscribeChanges(t *testing.T) {
+	release := make(chan struct{})
+	c, _, closeSrv := getWatcherTestingSrv(release)
+	defer closeSrv()
+
+	w := c.Stats.NewWatcher(EngineStock, "shares", nil)
+	w.Interval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := w.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	// the second poll is released after the rows of the first one are received
+	release <- struct{}{}
+	for i := 0; i < watcherTestingRows; i++ {
+		if got := receiveChange(t, ch); !got.IsNew || got.Fields != nil {
+			t.Fatalf("Error: expecting a new row \ngot %v \ninstead", got)
+		}
+	}
+	release <- struct{}{}
+	got := receiveChange(t, ch)
+	if got.IsNew || got.SecStat.Ticker != "DSKY" || got.SecStat.BoardId != "TQBR" || got.SecStat.Last != 93 {
+		t.Fatalf("Error: expecting the changed LAST of DSKY on TQBR \ngot %v \ninstead", got)
+	}
+	if expected := []string{secStatKeyLast}; !reflect.DeepEqual(got.Fields, expected) {
+		t.Fatalf("Error: expecting changed fields %v \ngot %v \ninstead", expected, got.Fields)
+	}
+
+	cancel()
+	close(release)
+	for range ch {
+		// the rest of changes are dropped until the channel is closed
+	}
+}
+
+func TestWatcher_SharedPoll(t *testing.T) {
+	release := make(chan struct{})
+	c, requests, closeSrv := getWatcherTestingSrv(release)
+	defer closeSrv()
+
+	w := c.Stats.NewWatcher(EngineStock, "shares", nil)
+	w.Interval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first, _ := w.Subscribe(ctx)
+	second, _ := w.Subscribe(ctx)
+	close(release)
+
+	// every subscriber gets all the rows of the shared poll
+	for _, ch := range []<-chan SecStatChange{first, second} {
+		for i := 0; i < watcherTestingRows; i++ {
+			receiveChange(t, ch)
+		}
+	}
+	if got, expected := atomic.LoadInt32(requests), int32(1); got != expected {
+		t.Fatalf("Error: expecting %d request \ngot %d \ninstead", expected, got)
+	}
+}
+
+func TestWatcher_SlowSubscriber(t *testing.T) {
+	c, requests, closeSrv := getWatcherTestingSrv(nil)
+	defer closeSrv()
+
+	w := c.Stats.NewWatcher(EngineStock, "shares", nil)
+	w.Interval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	slow, _ := w.Subscribe(ctx)
+	fast, _ := w.Subscribe(ctx)
+
+	// the fast subscriber gets the changes of the next polls while the slow one reads nothing
+	for i := 0; i < watcherTestingRows; i++ {
+		receiveChange(t, fast)
+	}
+	if got := receiveChange(t, fast); got.SecStat.Ticker != "DSKY" || got.SecStat.Last != 93 {
+		t.Fatalf("Error: expecting the changed LAST of DSKY on TQBR \ngot %v \ninstead", got)
+	}
+	for atomic.LoadInt32(requests) < 4 {
+		time.Sleep(w.Interval)
+	}
+	// the slow subscriber gets the latest rows
+	for {
+		got := receiveChange(t, slow)
+		if got.SecStat.Ticker == "DSKY" && got.SecStat.BoardId == "TQBR" && got.SecStat.Last == 93 {
+			break
+		}
+	}
+}
+
+func TestWatcher_OnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	errs := make(chan error, 1)
+	w := c.Stats.NewWatcher(EngineStock, "shares", nil)
+	w.Interval = time.Hour
+	w.OnError = func(err error) {
+		errs <- err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, _ := w.Subscribe(ctx)
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatalf("Error: expecting an error of the poll")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Error: expecting an error of the poll \ngot a timeout \ninstead")
+	}
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Fatalf("Error: expecting the closed channel")
+	}
+}
+
+func TestWatcher_SubscribeErrors(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	if _, got := c.Stats.NewWatcher(EngineStock, "shares", nil).Subscribe(ctx); got != ErrNonNilContext {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrNonNilContext, got)
+	}
+	if _, got := c.Stats.NewWatcher(EngineUndefined, "shares", nil).Subscribe(context.Background()); got != ErrBadEngineParameter {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrBadEngineParameter, got)
+	}
+}
+
+func TestWatcher_Backoff(t *testing.T) {
+	w := &Watcher{Interval: time.Second, MaxBackoff: 5 * time.Second}
+	for failures, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if got := w.backoff(failures); got != expected {
+			t.Fatalf("Error: expecting backoff %v after %d failures \ngot %v \ninstead", expected, failures, got)
+		}
+	}
+	if got, expected := (&Watcher{}).backoff(100), defaultWatcherMaxBackoff; got != expected {
+		t.Fatalf("Error: expecting backoff %v \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestWatcher_NextOpen(t *testing.T) {
+	w := &Watcher{}
+	if _, ok := w.nextOpen(time.Now()); ok {
+		t.Fatalf("Error: expecting an open market without the schedule")
+	}
+	w.Schedule = &TradingSchedule{}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		w.Schedule.WeekDays = append(w.Schedule.WeekDays, WeekDaySchedule{WeekDay: d, IsWorkDay: true, Start: 10 * time.Hour, Stop: 18 * time.Hour})
+	}
+	if _, ok := w.nextOpen(time.Date(2021, 2, 24, 12, 0, 0, 0, MoscowLocation)); ok {
+		t.Fatalf("Error: expecting an open market during the session")
+	}
+	open, ok := w.nextOpen(time.Date(2021, 2, 24, 20, 0, 0, 0, MoscowLocation))
+	if expected := time.Date(2021, 2, 25, 10, 0, 0, 0, MoscowLocation); !ok || !open.Equal(expected) {
+		t.Fatalf("Error: expecting the next open %v \ngot %v, %v \ninstead", expected, open, ok)
+	}
+}
+
+func TestMergeSecStatChanges(t *testing.T) {
+	prev := SecStatChange{SecStat: SecStat{Ticker: "SBER", Last: 271}, Fields: []string{secStatKeyTime, secStatKeyLast}}
+	cur := SecStatChange{SecStat: SecStat{Ticker: "SBER", Last: 272}, Fields: []string{secStatKeyLast, secStatKeyNumTrades}}
+	expected := SecStatChange{SecStat: cur.SecStat, Fields: []string{secStatKeyTime, secStatKeyLast, secStatKeyNumTrades}}
+	if got := mergeSecStatChanges(prev, cur); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	prev = SecStatChange{SecStat: SecStat{Ticker: "SBER", Last: 271}, IsNew: true}
+	expected = SecStatChange{SecStat: cur.SecStat, IsNew: true}
+	if got := mergeSecStatChanges(prev, cur); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestChangedSecStatFields(t *testing.T) {
+	prev := SecStat{Ticker: "GAZP", Last: 250, NumTrades: 10}
+	cur := SecStat{Ticker: "GAZP", Last: 251, NumTrades: 11}
+	expected := []string{secStatKeyLast, secStatKeyNumTrades}
+	if got := changedSecStatFields(prev, cur); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Error: expecting changed fields %v \ngot %v \ninstead", expected, got)
+	}
+	if got := changedSecStatFields(prev, prev); got != nil {
+		t.Fatalf("Error: expecting no changed fields \ngot %v \ninstead", got)
+	}
+}
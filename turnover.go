@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"github.com/buger/jsonparser"
+	"time"
 )
 
 // Turnover struct represents market turnovers
@@ -16,10 +17,38 @@ type Turnover struct {
 	NumTrades   int64   // "NUMTRADES" Quantity of Trades per Day, units
 	UpdateTime  string  // "UPDATETIME" Time of Last Updating
 	Title       string  // "TITLE" Market title
+	TitleRu     string  // "TITLE" in Russian, it's filled by GetTurnoversBilingual only
+	TitleEn     string  // "TITLE" in English, it's filled by GetTurnoversBilingual only
+}
+
+// TurnoverDelta struct represents a change of a turnover value against the previous date
+type TurnoverDelta struct {
+	Absolute float64 // the current value minus the previous one
+	Percent  float64 // the change in percents of the previous value, it's 0 if the previous value is 0
+}
+
+// TurnoverComparison struct represents turnovers of a market for the current and the previous dates
+type TurnoverComparison struct {
+	Name        string        // Market text identifier
+	Current     Turnover      // "turnovers" block, it's zero if the market is only in the previous date block
+	Previous    Turnover      // "turnoversprevdate" block, it's zero if the market has no previous date turnovers
+	ValToday    TurnoverDelta // change of Turnover.ValToday
+	ValTodayUsd TurnoverDelta // change of Turnover.ValTodayUsd
+	NumTrades   TurnoverDelta // change of Turnover.NumTrades
+}
+
+// TurnoversOfDate struct represents turnovers of the markets for a date of a time series
+type TurnoversOfDate struct {
+	Date      time.Time
+	Turnovers []Turnover
 }
 
 const (
 	turnoverPartsUrl = "turnovers.json"
+	turnoverEndpoint = "turnovers"
+
+	// turnoversRangeMaxDays limits the number of dates of GetTurnoversRange
+	turnoversRangeMaxDays = 366
 
 	turnoverKeyName        = "NAME"
 	turnoverKeyId          = "ID"
@@ -38,9 +67,12 @@ type TurnoverService service
 
 // GetTurnovers provides a list of turnovers of markets of MoEx ISS
 func (s *TurnoverService) GetTurnovers(ctx context.Context, opt *TurnoverRequestOptions) (*[]Turnover, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
 
 	url := s.getUrl(opt, turnoversBlock)
-	req, err := s.client.NewRequest("GET", url, nil)
+	req, err := s.client.newEndpointRequest(turnoverEndpoint, url)
 	if err != nil {
 		return nil, err
 	}
@@ -61,15 +93,129 @@ func (s *TurnoverService) GetTurnovers(ctx context.Context, opt *TurnoverRequest
 
 }
 
+// GetTurnoversWithPrevDate provides turnovers of markets of MoEx ISS for the current and the previous dates
+// with changes of the values, markets keep the order of the current date block
+func (s *TurnoverService) GetTurnoversWithPrevDate(ctx context.Context, opt *TurnoverRequestOptions) ([]TurnoverComparison, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	url := s.getUrl(opt, turnoversBlock, turnoversPrevDateBlock)
+	req, err := s.client.newEndpointRequest(turnoverEndpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = s.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	current := make([]Turnover, 0)
+	err = parseTurnoverBlock(b.Bytes(), turnoversBlock, &current)
+	if err != nil {
+		return nil, err
+	}
+	previous := make([]Turnover, 0)
+	err = parseTurnoverBlock(b.Bytes(), turnoversPrevDateBlock, &previous)
+	if err != nil {
+		return nil, err
+	}
+	return CompareTurnovers(current, previous), nil
+}
+
+// GetTurnoversRange provides a time series of turnovers of markets requesting every date from 'from' till 'till'
+// inclusively(see TurnoverReqOptionsBuilder.Date), dates without turnovers(e.g. weekends) are skipped.
+// The calendar dates of 'from' and 'till' are taken in their own locations,
+// the dates of the series are midnights of them in MoscowLocation.
+// Every date is a separate request, so it returns ErrBadDateRangeParameter
+// if a date is zero, 'till' is before 'from' or the range is longer than turnoversRangeMaxDays.
+func (s *TurnoverService) GetTurnoversRange(ctx context.Context, from time.Time, till time.Time, opt *TurnoverRequestOptions) ([]TurnoversOfDate, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	dates, err := rangeDates(from, till, turnoversRangeMaxDays)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateNotFutureDate("till", till); err != nil {
+		return nil, err
+	}
+	dateOpt := TurnoverRequestOptions{}
+	if opt != nil {
+		dateOpt = *opt
+	}
+	series := make([]TurnoversOfDate, 0)
+	for _, date := range dates {
+		dateOpt.date = date
+		turnovers, err := s.GetTurnovers(ctx, &dateOpt)
+		if err != nil {
+			return nil, err
+		}
+		if len(*turnovers) == 0 {
+			continue
+		}
+		series = append(series, TurnoversOfDate{Date: date, Turnovers: *turnovers})
+	}
+	return series, nil
+}
+
+// CompareTurnovers matches turnovers of the current and the previous dates by market names
+// and computes changes of the values
+func CompareTurnovers(current []Turnover, previous []Turnover) []TurnoverComparison {
+	prevByName := make(map[string]Turnover, len(previous))
+	for _, t := range previous {
+		prevByName[t.Name] = t
+	}
+	comparisons := make([]TurnoverComparison, 0, len(current))
+	seen := make(map[string]bool, len(current))
+	for _, t := range current {
+		seen[t.Name] = true
+		comparisons = append(comparisons, newTurnoverComparison(t.Name, t, prevByName[t.Name]))
+	}
+	for _, t := range previous {
+		if seen[t.Name] {
+			continue
+		}
+		comparisons = append(comparisons, newTurnoverComparison(t.Name, Turnover{}, t))
+	}
+	return comparisons
+}
+
+func newTurnoverComparison(name string, current Turnover, previous Turnover) TurnoverComparison {
+	return TurnoverComparison{
+		Name:        name,
+		Current:     current,
+		Previous:    previous,
+		ValToday:    newTurnoverDelta(current.ValToday, previous.ValToday),
+		ValTodayUsd: newTurnoverDelta(current.ValTodayUsd, previous.ValTodayUsd),
+		NumTrades:   newTurnoverDelta(float64(current.NumTrades), float64(previous.NumTrades)),
+	}
+}
+
+func newTurnoverDelta(current float64, previous float64) TurnoverDelta {
+	d := TurnoverDelta{Absolute: current - previous}
+	if previous != 0 {
+		d.Percent = d.Absolute / previous * 100
+	}
+	return d
+}
+
 // getUrl provides an url for a request of the turnovers with parameters from TurnoverRequestOptions
 // opt *TurnoverRequestOptions can be nil, it is safe
-func (s *TurnoverService) getUrl(opt *TurnoverRequestOptions, onlyBlock turnoverBlock) string {
+func (s *TurnoverService) getUrl(opt *TurnoverRequestOptions, onlyBlocks ...turnoverBlock) string {
 	url, _ := s.client.BaseURL.Parse(turnoverPartsUrl)
-	gotURL := addTurnoverRequestOptions(url, opt, onlyBlock)
+	gotURL := addTurnoverRequestOptions(url, opt, onlyBlocks...)
 	return gotURL.String()
 }
 
 func parseTurnoverResponse(byteData []byte, turnovers *[]Turnover) error {
+	return parseTurnoverBlock(byteData, turnoversBlock, turnovers)
+}
+
+// parseTurnoverBlock parses the 'block' block of turnovers
+func parseTurnoverBlock(byteData []byte, block turnoverBlock, turnovers *[]Turnover) error {
 	var err error
 	if turnovers == nil {
 		err = ErrNilPointer
@@ -79,7 +225,7 @@ func parseTurnoverResponse(byteData []byte, turnovers *[]Turnover) error {
 	_, err = jsonparser.ArrayEach(byteData, func(turnoversBytes []byte, _ jsonparser.ValueType, offset int, errCb error) {
 		var data []byte
 		var dataType jsonparser.ValueType
-		data, dataType, _, errInCb = jsonparser.Get(turnoversBytes, "turnovers")
+		data, dataType, _, errInCb = jsonparser.Get(turnoversBytes, block.String())
 		if errInCb == nil && data != nil && dataType == jsonparser.Array {
 			errInCb = parseTurnovers(data, turnovers)
 			if errInCb != nil {
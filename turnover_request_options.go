@@ -2,6 +2,7 @@ package moexiss
 
 import (
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -46,6 +47,38 @@ func (b *TurnoverReqOptionsBuilder) Build() *TurnoverRequestOptions {
 	return b.options
 }
 
+//BuildValidated builds TurnoverRequestOptions from TurnoverReqOptionsBuilder
+//and returns *ValidationError if an option is invalid
+func (b *TurnoverReqOptionsBuilder) BuildValidated() (*TurnoverRequestOptions, error) {
+	if err := b.options.validate(); err != nil {
+		return nil, err
+	}
+	return b.options, nil
+}
+
+//validate checks parameters of TurnoverRequestOptions,
+//options can be nil, it is safe
+func (options *TurnoverRequestOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+	return firstError(
+		validateLang("lang", options.lang),
+		validateNotFutureDate("date", options.date),
+	)
+}
+
+//withLang returns a copy of the options with the language,
+//options can be nil, it is safe
+func (options *TurnoverRequestOptions) withLang(lang Language) *TurnoverRequestOptions {
+	opt := TurnoverRequestOptions{}
+	if options != nil {
+		opt = *options
+	}
+	opt.lang = lang
+	return &opt
+}
+
 //Lang sets 'lang' parameter to a request of the current turnover
 func (b *TurnoverReqOptionsBuilder) Lang(lang Language) *TurnoverReqOptionsBuilder {
 	b.options.lang = lang
@@ -65,12 +98,19 @@ func (b *TurnoverReqOptionsBuilder) Date(date time.Time) *TurnoverReqOptionsBuil
 }
 
 //addTurnoverRequestOptions sets parameters into *url.URL
-//from TurnoverRequestOptions struct and returns it back
-func addTurnoverRequestOptions(url *url.URL, options *TurnoverRequestOptions, onlyBlock turnoverBlock) *url.URL {
+//from TurnoverRequestOptions struct and returns it back,
+//'iss.only' parameter lists the blocks which aren't turnoversBlockUndefined
+func addTurnoverRequestOptions(url *url.URL, options *TurnoverRequestOptions, onlyBlocks ...turnoverBlock) *url.URL {
 	q := url.Query()
 	q.Set("iss.meta", "off")
-	if onlyBlock != turnoversBlockUndefined {
-		q.Set("iss.only", onlyBlock.String())
+	blocks := make([]string, 0, len(onlyBlocks))
+	for _, block := range onlyBlocks {
+		if block != turnoversBlockUndefined {
+			blocks = append(blocks, block.String())
+		}
+	}
+	if len(blocks) > 0 {
+		q.Set("iss.only", strings.Join(blocks, ","))
 	}
 	q.Set("iss.json", "extended")
 	if options == nil {
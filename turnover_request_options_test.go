@@ -84,6 +84,17 @@ func TestAddTurnoverRequestOptions(t *testing.T) {
 	}
 }
 
+func TestAddTurnoverRequestOptionsSeveralBlocks(t *testing.T) {
+	c := NewClient(nil)
+	url, _ := c.BaseURL.Parse("turnovers.json")
+	gotURL := addTurnoverRequestOptions(url, nil, turnoversBlock, turnoversBlockUndefined, turnoversPrevDateBlock)
+
+	expected := `https://iss.moex.com/iss/turnovers.json?iss.json=extended&iss.meta=off&iss.only=turnovers%2Cturnoversprevdate`
+	if got := gotURL.String(); got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
 func TestTurnoverBlock_String(t *testing.T) {
 	type Case struct {
 		income   turnoverBlock
@@ -108,3 +119,16 @@ func TestTurnoverBlock_String(t *testing.T) {
 		}
 	}
 }
+
+func TestTurnoverReqOptionsBuilder_BuildValidated(t *testing.T) {
+	checkBuilderCases(t, []builderCase{
+		{func() (bool, error) {
+			opt, err := NewTurnoverReqOptionsBuilder().Date(time.Date(2021, 2, 24, 12, 0, 0, 0, time.UTC)).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewTurnoverReqOptionsBuilder().Date(time.Now().AddDate(0, 0, 2)).BuildValidated()
+			return opt != nil, err
+		}, "date"},
+	})
+}
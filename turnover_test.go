@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestParseTurnoverResponse(t *testing.T) {
@@ -302,3 +303,173 @@ func TestTurnoversKeyPathNotFound(t *testing.T) {
 		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
 	}
 }
+
+func TestParseTurnoverBlock(t *testing.T) {
+	byteValue, err := getTestingData("turnover_prevdate.json")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	turnovers := make([]Turnover, 0)
+	if err = parseTurnoverBlock(byteValue, turnoversPrevDateBlock, &turnovers); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(turnovers), 4; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := turnovers[2].Name, "futures"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestCompareTurnovers(t *testing.T) {
+	current := []Turnover{
+		{Name: "stock", ValToday: 150, ValTodayUsd: 2, NumTrades: 90},
+		{Name: "commodity"},
+	}
+	previous := []Turnover{
+		{Name: "futures", ValToday: 10},
+		{Name: "stock", ValToday: 100, ValTodayUsd: 4, NumTrades: 100},
+	}
+	expected := []TurnoverComparison{
+		{Name: "stock", Current: current[0], Previous: previous[1],
+			ValToday:    TurnoverDelta{Absolute: 50, Percent: 50},
+			ValTodayUsd: TurnoverDelta{Absolute: -2, Percent: -50},
+			NumTrades:   TurnoverDelta{Absolute: -10, Percent: -10}},
+		{Name: "commodity", Current: current[1]},
+		{Name: "futures", Previous: previous[0],
+			ValToday: TurnoverDelta{Absolute: -10, Percent: -100}},
+	}
+	got := CompareTurnovers(current, previous)
+	if len(got) != len(expected) {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", len(expected), len(got))
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead in %d item", expected[i], got[i], i)
+		}
+	}
+}
+
+func TestTurnoverService_TurnoversWithPrevDate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, expected := r.URL.Query().Get("iss.only"), "turnovers,turnoversprevdate"; got != expected {
+			t.Errorf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+		}
+		byteValue, err := getTestingData("turnover_prevdate.json")
+		if err != nil {
+			t.Errorf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.Turnovers.GetTurnoversWithPrevDate(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(result), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := result[1].NumTrades.Absolute, float64(-18235); got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := result[4].Name, "futures"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestTurnoverService_TurnoversWithPrevDateKeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	_, err := c.Turnovers.GetTurnoversWithPrevDate(context.Background(), nil)
+	if got, expected := err, jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestTurnoverService_TurnoversRange(t *testing.T) {
+	dates := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		dates = append(dates, date)
+		if date == "2021-02-21" {
+			_, _ = w.Write([]byte(`[{"charsetinfo": {"name": "utf-8"}}, {"turnovers": []}]`))
+			return
+		}
+		TestingTurnoverHandler(w, r)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	from := time.Date(2021, 2, 20, 15, 0, 0, 0, MoscowLocation)
+	till := time.Date(2021, 2, 22, 0, 0, 0, 0, MoscowLocation)
+	series, err := c.Turnovers.GetTurnoversRange(context.Background(), from, till, NewTurnoverReqOptionsBuilder().Lang(LangEn).Build())
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := fmt.Sprint(dates), "[2021-02-20 2021-02-21 2021-02-22]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := len(series), 2; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+	if got, expected := series[1].Date, time.Date(2021, 2, 22, 0, 0, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := len(series[1].Turnovers), 5; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+}
+
+func TestTurnoverService_TurnoversRangeError(t *testing.T) {
+	c := NewClient(nil)
+	from := time.Date(2021, 2, 22, 0, 0, 0, 0, MoscowLocation)
+	till := time.Date(2021, 2, 20, 0, 0, 0, 0, MoscowLocation)
+	var cases = []struct {
+		name string
+		from time.Time
+		till time.Time
+	}{
+		{"till before from", from, till},
+		{"zero from", time.Time{}, from},
+		{"zero till", till, time.Time{}},
+		{"too long range", till.AddDate(0, 0, -turnoversRangeMaxDays), till},
+	}
+	for _, cs := range cases {
+		_, err := c.Turnovers.GetTurnoversRange(context.Background(), cs.from, cs.till, nil)
+		if got, expected := err, ErrBadDateRangeParameter; got != expected {
+			t.Fatalf("Error: expecting %v error of %s \ngot %v \ninstead", expected, cs.name, got)
+		}
+	}
+}
+
+func TestTurnoverService_TurnoversRangeLocations(t *testing.T) {
+	dates := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dates = append(dates, r.URL.Query().Get("date"))
+		TestingTurnoverHandler(w, r)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	// 2021-02-20 23:00 in UTC-10 is 2021-02-21 12:00 in Moscow, the calendar date of the caller is used
+	from := time.Date(2021, 2, 20, 23, 0, 0, 0, time.FixedZone("UTC-10", -10*60*60))
+	till := time.Date(2021, 2, 21, 1, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	series, err := c.Turnovers.GetTurnoversRange(context.Background(), from, till, nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := fmt.Sprint(dates), "[2021-02-20 2021-02-21]"; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if got, expected := series[0].Date, time.Date(2021, 2, 20, 0, 0, 0, 0, MoscowLocation); !got.Equal(expected) {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
@@ -0,0 +1,107 @@
+package moexiss
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidOption is matched by errors.Is for every ValidationError
+var ErrInvalidOption = errors.New("invalid request option")
+
+// ValidationError represents an invalid option of a request,
+// it is returned by BuildValidated methods of request options builders
+// and by services before any request to MoEx ISS API
+type ValidationError struct {
+	Option string // the query parameter of the option, e.g. "securities"
+	Value  string // the invalid value
+	Reason string // why the value is invalid
+}
+
+// Error returns a description of the invalid option
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid '%s' option %q: %s", e.Option, e.Value, e.Reason)
+}
+
+// Unwrap makes errors.Is(err, ErrInvalidOption) true for the error
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidOption
+}
+
+// firstError returns the first non-nil error
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOneOf checks the value is one of the allowed values
+func validateOneOf(option string, value string, reason string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return &ValidationError{Option: option, Value: value, Reason: reason}
+}
+
+// validateLang checks the language is known, LangUndefined is valid
+func validateLang(option string, lang Language) error {
+	// Language.String hides unknown values
+	return validateOneOf(option, string(lang), "unknown language",
+		string(LangUndefined), string(LangEn), string(LangRu))
+}
+
+// validateNotFutureDate checks the calendar date isn't after today in MoscowLocation,
+// a zero date is valid
+func validateNotFutureDate(option string, date time.Time) error {
+	if date.IsZero() {
+		return nil
+	}
+	now := time.Now().In(MoscowLocation)
+	if calendarDate(date).After(calendarDate(now)) {
+		return &ValidationError{Option: option, Value: date.Format(issDateLayout), Reason: "the date is in the future"}
+	}
+	return nil
+}
+
+// validateDateRange checks 'till' date isn't before 'from' date, it's valid if any of them is zero
+func validateDateRange(from time.Time, till time.Time) error {
+	if from.IsZero() || till.IsZero() {
+		return nil
+	}
+	if calendarDate(till).Before(calendarDate(from)) {
+		return &ValidationError{Option: "till", Value: till.Format(issDateLayout), Reason: "the date is before 'from' date"}
+	}
+	return nil
+}
+
+// calendarDate returns the calendar date of the time in its location at midnight UTC
+// to compare dates regardless of locations
+func calendarDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// rangeDates returns midnights in MoscowLocation of the calendar dates from 'from' till 'till' inclusively,
+// the calendar dates are taken in their own locations.
+// It returns ErrBadDateRangeParameter if a date is zero, 'till' is before 'from'
+// or there are more than maxDays dates.
+func rangeDates(from time.Time, till time.Time, maxDays int) ([]time.Time, error) {
+	if from.IsZero() || till.IsZero() {
+		return nil, ErrBadDateRangeParameter
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, MoscowLocation)
+	till = time.Date(till.Year(), till.Month(), till.Day(), 0, 0, 0, 0, MoscowLocation)
+	if till.Before(from) || till.After(from.AddDate(0, 0, maxDays-1)) {
+		return nil, ErrBadDateRangeParameter
+	}
+	dates := make([]time.Time, 0)
+	for date := from; !date.After(till); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
@@ -0,0 +1,263 @@
+package moexiss
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestValidationError(t *testing.T) {
+	var err error = &ValidationError{Option: "boardid", Value: "", Reason: "empty board"}
+	if got, expected := err.Error(), `invalid 'boardid' option "": empty board`; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrInvalidOption, err)
+	}
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.Option != "boardid" {
+		t.Fatalf("Error: expecting *ValidationError \ngot %v \ninstead", err)
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	err := errors.New("the first")
+	if got, expected := firstError(nil, err, ErrNilPointer), err; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+	if got := firstError(); got != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", got)
+	}
+}
+
+// builderCase is a case of BuildValidated of a request options builder,
+// build reports whether the options are built
+type builderCase struct {
+	build  func() (bool, error)
+	option string // the option of the expected *ValidationError, empty if the options are valid
+}
+
+func checkBuilderCases(t *testing.T, cases []builderCase) {
+	t.Helper()
+	for i, c := range cases {
+		built, err := c.build()
+		if c.option == "" {
+			if err != nil || !built {
+				t.Fatalf("Error: expecting options and <nil> error \ngot %v \ninstead in %d case", err, i)
+			}
+			continue
+		}
+		if built {
+			t.Fatalf("Error: expecting <nil> options \ngot options \ninstead in %d case", i)
+		}
+		ve, ok := err.(*ValidationError)
+		if !ok || ve.Option != c.option {
+			t.Fatalf("Error: expecting *ValidationError of `%s` \ngot %v \ninstead in %d case", c.option, err, i)
+		}
+	}
+}
+
+func TestReqOptionsBuilders_BuildValidated(t *testing.T) {
+	checkBuilderCases(t, []builderCase{
+		// Aggregate
+		{func() (bool, error) {
+			opt, err := NewAggregateReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewAggregateReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// Archives
+		{func() (bool, error) {
+			opt, err := NewArchivesReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewArchivesReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// CorporateActions
+		{func() (bool, error) {
+			opt, err := NewCorporateActionsReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewCorporateActionsReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// DateRange
+		{func() (bool, error) {
+			opt, err := NewDateRangeReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewDateRangeReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// Derivatives
+		{func() (bool, error) {
+			opt, err := NewDerivativesReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewDerivativesReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// Engines
+		{func() (bool, error) {
+			opt, err := NewEnginesReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewEnginesReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// HistoryListing
+		{func() (bool, error) {
+			opt, err := NewHistoryListingReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewHistoryListingReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// Indices
+		{func() (bool, error) {
+			opt, err := NewIndicesReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewIndicesReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// News
+		{func() (bool, error) {
+			opt, err := NewNewsReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewNewsReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// Schedule
+		{func() (bool, error) {
+			opt, err := NewScheduleReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewScheduleReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// SecurityGroups
+		{func() (bool, error) {
+			opt, err := NewSecurityGroupsReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewSecurityGroupsReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+		// Turnover
+		{func() (bool, error) {
+			opt, err := NewTurnoverReqOptionsBuilder().Lang(LangEn).BuildValidated()
+			return opt != nil, err
+		}, ""},
+		{func() (bool, error) {
+			opt, err := NewTurnoverReqOptionsBuilder().Lang(Language("de")).BuildValidated()
+			return opt != nil, err
+		}, "lang"},
+	})
+}
+
+func TestValidateLang(t *testing.T) {
+	for _, lang := range []Language{LangUndefined, LangEn, LangRu} {
+		if err := validateLang("lang", lang); err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+	}
+	if got, expected := validateLang("lang", Language("de")), `invalid 'lang' option "de": unknown language`; got == nil || got.Error() != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestValidateNotFutureDate(t *testing.T) {
+	now := time.Now()
+	for _, date := range []time.Time{{}, now, now.In(MoscowLocation), now.AddDate(-1, 0, 0)} {
+		if err := validateNotFutureDate("date", date); err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+	}
+	if err := validateNotFutureDate("date", now.AddDate(0, 0, 2)); !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", ErrInvalidOption, err)
+	}
+}
+
+func TestValidateDateRange(t *testing.T) {
+	from := time.Date(2023, 12, 1, 23, 0, 0, 0, time.UTC)
+	type Case struct {
+		from  time.Time
+		till  time.Time
+		valid bool
+	}
+	cases := []Case{
+		{time.Time{}, from, true},
+		{from, time.Time{}, true},
+		{from, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), true},
+		{from, time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC), false},
+	}
+	for i, c := range cases {
+		if got := validateDateRange(c.from, c.till); (got == nil) != c.valid {
+			t.Fatalf("Error: expecting valid %v \ngot %v \ninstead in %d case", c.valid, got, i)
+		}
+	}
+}
+
+func TestServicesRejectInvalidOptions(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	ctx := context.Background()
+	future := time.Now().AddDate(0, 0, 2)
+	errs := make([]error, 0)
+	collect := func(_ interface{}, err error) {
+		errs = append(errs, err)
+	}
+	collect(c.Turnovers.GetTurnovers(ctx, NewTurnoverReqOptionsBuilder().Date(future).Build()))
+	collect(c.Turnovers.GetTurnoversRange(ctx, time.Now(), future, nil))
+	collect(c.Stats.GetSecStats(ctx, EngineStock, "shares", NewStatReqOptionsBuilder().AddTicker("").Build()))
+	collect(c.Index.List(ctx, NewIndexReqOptionsBuilder().Engine().Lang(Language("de")).Build()))
+	collect(c.HistoryListing.GetListing(ctx, EngineStock, "shares", NewHistoryListingReqOptionsBuilder().Status("halted").Build()))
+	collect(c.Aggregates.GetAggregates(ctx, "SBER", NewAggregateReqOptionsBuilder().Date(future).Build()))
+	collect(c.Aggregates.GetAggregatesBatch(ctx, []string{"SBER", "GAZP"}, NewAggregateReqOptionsBuilder().Date(future).Build()))
+	collect(c.Indices.GetIndicesBatch(ctx, []string{"SBER", "GAZP"}, NewIndicesReqOptionsBuilder().Lang("de").Build()))
+	collect(c.ZCYC.GetZCYC(ctx, NewDateRangeReqOptionsBuilder().Date(future).Build()))
+	collect(c.Rates.GetFixings(ctx, NewDateRangeReqOptionsBuilder().Date(future).Build()))
+	collect(c.FutOI.GetFutOIAll(ctx, NewFutOIReqOptionsBuilder().Till(future).Build()))
+	collect(c.Capitalization.GetCapitalization(ctx, NewDateRangeReqOptionsBuilder().Till(future).Build()))
+	collect(c.Derivatives.GetFuturesSeries(ctx, "Si", NewDerivativesReqOptionsBuilder().Lang("de").Build()))
+	collect(c.Engines.List(ctx, NewEnginesReqOptionsBuilder().Lang("de").Build()))
+	collect(c.Schedule.Schedule(ctx, EngineStock, NewScheduleReqOptionsBuilder().Lang("de").Build()))
+	collect(c.News.SiteNews(ctx, NewNewsReqOptionsBuilder().Lang("de").Build()))
+	collect(c.CorporateActions.Splits(ctx, NewCorporateActionsReqOptionsBuilder().Lang("de").Build()))
+	collect(c.SecurityGroups.CollectionSecuritiesAll(ctx, "stock_shares", "stock_shares_one", NewSecurityGroupsReqOptionsBuilder().Lang("de").Build()))
+	collect(c.Archives.List(ctx, EngineStock, "shares", ArchiveSecurities, ArchiveYearly, NewArchivesReqOptionsBuilder().Lang("de").Build()))
+
+	for i, err := range errs {
+		if !errors.Is(err, ErrInvalidOption) {
+			t.Fatalf("Error: expecting %v error \ngot %v \ninstead in %d case", ErrInvalidOption, err, i)
+		}
+	}
+	if requests != 0 {
+		t.Fatalf("Error: expecting no requests \ngot %d requests \ninstead", requests)
+	}
+}
@@ -0,0 +1,325 @@
+package moexiss
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/buger/jsonparser"
+	"math"
+	"path"
+	"strconv"
+)
+
+// ZCYCParams struct represents parameters of the zero-coupon yield curve (G-curve).
+// Beta0, Beta1, Beta2 and G values are in basis points, Tau is in years.
+type ZCYCParams struct {
+	TradeDate string     // "tradedate"
+	TradeTime string     // "tradetime"
+	Beta0     float64    // "B1"
+	Beta1     float64    // "B2"
+	Beta2     float64    // "B3"
+	Tau       float64    // "T1"
+	G         [9]float64 // "G1".."G9"
+}
+
+// ZCYCYearYield struct represents a tabulated point of the zero-coupon yield curve
+type ZCYCYearYield struct {
+	TradeDate string  // "tradedate"
+	TradeTime string  // "tradetime"
+	Period    float64 // "period" Tenor, years
+	Value     float64 // "value" Yield, % per annum
+}
+
+// ZCYCResponse struct represents a response with the zero-coupon yield curve
+type ZCYCResponse struct {
+	Params     []ZCYCParams
+	YearYields []ZCYCYearYield
+}
+
+const (
+	zcycPartsUrl        = "zcyc.json"
+	zcycEndpoint        = "engines/{engine}/zcyc"
+	zcycHistoryEndpoint = "history/engines/{engine}/zcyc"
+
+	zcycKeyTradeDate  = "tradedate"
+	zcycKeyTradeTime  = "tradetime"
+	zcycKeyBeta0      = "B1"
+	zcycKeyBeta1      = "B2"
+	zcycKeyBeta2      = "B3"
+	zcycKeyTau        = "T1"
+	zcycKeyPeriod     = "period"
+	zcycKeyValue      = "value"
+	zcycKeyParams     = "params"
+	zcycKeyYearYields = "yearyields"
+
+	// zcycGKeyPrefix is the prefix of "G1".."G9" keys
+	zcycGKeyPrefix = "G"
+
+	// zcycK, zcycA1 and zcycA2 are fixed parameters of the Svensson part
+	// of the G-curve according to the Bank of Russia methodology
+	zcycK  = 1.6
+	zcycA1 = 0.0
+	zcycA2 = 0.6
+)
+
+// ZCYCService gets the zero-coupon yield curve of government bonds
+// from the MoEx ISS API.
+//
+// MoEx ISS API endpoints:
+// https://iss.moex.com/iss/engines/stock/zcyc
+// https://iss.moex.com/iss/history/engines/stock/zcyc
+type ZCYCService service
+
+// GetZCYC provides the zero-coupon yield curve for the date, see DateRangeReqOptionsBuilder.Date
+func (z *ZCYCService) GetZCYC(ctx context.Context, opt *DateRangeRequestOptions) (*ZCYCResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return z.get(ctx, zcycEndpoint, z.getUrl(opt))
+}
+
+// GetZCYCHistory provides the zero-coupon yield curve parameters
+// for the period, see DateRangeReqOptionsBuilder.From and DateRangeReqOptionsBuilder.Till
+func (z *ZCYCService) GetZCYCHistory(ctx context.Context, opt *DateRangeRequestOptions) (*ZCYCResponse, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return z.get(ctx, zcycHistoryEndpoint, z.getHistoryUrl(opt))
+}
+
+func (z *ZCYCService) get(ctx context.Context, endpoint string, url string) (*ZCYCResponse, error) {
+	req, err := z.client.newEndpointRequest(endpoint, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+
+	_, err = z.client.Do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+	zr := ZCYCResponse{}
+	err = parseZCYCResponse(b.Bytes(), &zr)
+	if err != nil {
+		return nil, err
+	}
+	return &zr, nil
+}
+
+// getUrl provides an url for a request of the zero-coupon yield curve
+// opt *DateRangeRequestOptions can be nil, it is safe
+func (z *ZCYCService) getUrl(opt *DateRangeRequestOptions) string {
+	url, _ := z.client.BaseURL.Parse(enginePartOfPath)
+	url.Path = path.Join(url.Path, EngineStock.String(), zcycPartsUrl)
+	gotURL := addDateRangeRequestOptions(url, opt)
+	return gotURL.String()
+}
+
+// getHistoryUrl provides an url for a request of the zero-coupon yield curve history
+// opt *DateRangeRequestOptions can be nil, it is safe
+func (z *ZCYCService) getHistoryUrl(opt *DateRangeRequestOptions) string {
+	url, _ := z.client.BaseURL.Parse(historyPartOfPath)
+	url.Path = path.Join(url.Path, enginePartOfPath, EngineStock.String(), zcycPartsUrl)
+	gotURL := addDateRangeRequestOptions(url, opt)
+	return gotURL.String()
+}
+
+// Rate returns the continuously compounded zero-coupon rate
+// for the tenor in years, in basis points.
+// A tenor which isn't positive returns the limit of the curve at 0.
+func (p ZCYCParams) Rate(tenor float64) float64 {
+	rate := p.Beta0
+	if tenor <= 0 || p.Tau == 0 {
+		rate += p.Beta1
+	} else {
+		e := math.Exp(-tenor / p.Tau)
+		rate += (p.Beta1+p.Beta2)*(p.Tau/tenor)*(1-e) - p.Beta2*e
+	}
+	if tenor < 0 {
+		tenor = 0
+	}
+	a, b := zcycA1, zcycA2
+	for i, g := range p.G {
+		rate += g * math.Exp(-(tenor-a)*(tenor-a)/(b*b))
+		if i == 0 {
+			a = zcycA2
+		} else {
+			a += zcycA2 * math.Pow(zcycK, float64(i))
+		}
+		b *= zcycK
+	}
+	return rate
+}
+
+// Yield returns the effective annual zero-coupon yield for the tenor in years, in %
+func (p ZCYCParams) Yield(tenor float64) float64 {
+	return (math.Exp(p.Rate(tenor)/10000) - 1) * 100
+}
+
+// DiscountFactor returns the discount factor of a cash flow
+// paid after the tenor in years
+func (p ZCYCParams) DiscountFactor(tenor float64) float64 {
+	if tenor <= 0 {
+		return 1
+	}
+	return math.Exp(-p.Rate(tenor) / 10000 * tenor)
+}
+
+func parseZCYCResponse(byteData []byte, zcycResponse *ZCYCResponse) error {
+	var err error
+	if zcycResponse == nil {
+		err = ErrNilPointer
+		return err
+	}
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(zcycBytes []byte, _ jsonparser.ValueType, offset int, errCb error) {
+		var data []byte
+		var dataType jsonparser.ValueType
+		data, dataType, _, errInCb = jsonparser.Get(zcycBytes, zcycKeyParams)
+		if errInCb == nil && data != nil && dataType == jsonparser.Array {
+			errInCb = parseZCYCParams(data, &zcycResponse.Params)
+			if errInCb != nil {
+				return
+			}
+			data, dataType, _, _ = jsonparser.Get(zcycBytes, zcycKeyYearYields)
+			if data != nil && dataType == jsonparser.Array {
+				errInCb = parseZCYCYearYields(data, &zcycResponse.YearYields)
+			}
+		}
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return err
+}
+
+func parseZCYCParams(byteData []byte, params *[]ZCYCParams) (err error) {
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(paramsItemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.Object {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+
+		p := ZCYCParams{}
+		errInCb = parseZCYCParamsItem(paramsItemData, &p)
+		if errInCb != nil {
+			return
+		}
+		*params = append(*params, p)
+
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return
+}
+
+func parseZCYCParamsItem(data []byte, p *ZCYCParams) (err error) {
+	tradeDate, err := parseStringWithDefaultValueByKey(data, zcycKeyTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	tradeTime, err := parseStringWithDefaultValueByKey(data, zcycKeyTradeTime, "")
+	if err != nil {
+		return
+	}
+
+	beta0, err := parseFloatWithDefaultValue(data, zcycKeyBeta0)
+	if err != nil {
+		return
+	}
+
+	beta1, err := parseFloatWithDefaultValue(data, zcycKeyBeta1)
+	if err != nil {
+		return
+	}
+
+	beta2, err := parseFloatWithDefaultValue(data, zcycKeyBeta2)
+	if err != nil {
+		return
+	}
+
+	tau, err := parseFloatWithDefaultValue(data, zcycKeyTau)
+	if err != nil {
+		return
+	}
+
+	var g [9]float64
+	for i := range g {
+		g[i], err = parseFloatWithDefaultValue(data, zcycGKeyPrefix+strconv.Itoa(i+1))
+		if err != nil {
+			return
+		}
+	}
+
+	p.TradeDate = tradeDate
+	p.TradeTime = tradeTime
+	p.Beta0 = beta0
+	p.Beta1 = beta1
+	p.Beta2 = beta2
+	p.Tau = tau
+	p.G = g
+
+	return
+}
+
+func parseZCYCYearYields(byteData []byte, yields *[]ZCYCYearYield) (err error) {
+	var errInCb error
+	_, err = jsonparser.ArrayEach(byteData, func(yieldItemData []byte, dataType jsonparser.ValueType, offset int, errCb error) {
+		if errInCb != nil {
+			return
+		}
+		if dataType != jsonparser.Object {
+			errInCb = ErrUnexpectedDataType
+			return
+		}
+
+		y := ZCYCYearYield{}
+		errInCb = parseZCYCYearYield(yieldItemData, &y)
+		if errInCb != nil {
+			return
+		}
+		*yields = append(*yields, y)
+
+	})
+	if err == nil && errInCb != nil {
+		err = errInCb
+	}
+	return
+}
+
+func parseZCYCYearYield(data []byte, y *ZCYCYearYield) (err error) {
+	tradeDate, err := parseStringWithDefaultValueByKey(data, zcycKeyTradeDate, "")
+	if err != nil {
+		return
+	}
+
+	tradeTime, err := parseStringWithDefaultValueByKey(data, zcycKeyTradeTime, "")
+	if err != nil {
+		return
+	}
+
+	period, err := parseFloatWithDefaultValue(data, zcycKeyPeriod)
+	if err != nil {
+		return
+	}
+
+	value, err := parseFloatWithDefaultValue(data, zcycKeyValue)
+	if err != nil {
+		return
+	}
+
+	y.TradeDate = tradeDate
+	y.TradeTime = tradeTime
+	y.Period = period
+	y.Value = value
+
+	return
+}
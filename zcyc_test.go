@@ -0,0 +1,229 @@
+package moexiss
+
+import (
+	"context"
+	"github.com/buger/jsonparser"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestZCYCGetUrl(t *testing.T) {
+	c := NewClient(nil)
+	if got, expected := c.ZCYC.getUrl(nil), `https://iss.moex.com/iss/engines/stock/zcyc.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestZCYCGetHistoryUrl(t *testing.T) {
+	c := NewClient(nil)
+	if got, expected := c.ZCYC.getHistoryUrl(nil), `https://iss.moex.com/iss/history/engines/stock/zcyc.json?iss.json=extended&iss.meta=off`; got != expected {
+		t.Fatalf("Error: expecting url :\n`%s` \ngot \n`%s` \ninstead", expected, got)
+	}
+}
+
+func TestParseZCYCParamsItem(t *testing.T) {
+	expectedStruct := ZCYCParams{
+		TradeDate: "2022-03-04",
+		TradeTime: "18:59:59",
+		Beta0:     1112.81,
+		Beta1:     858.66,
+		Beta2:     -1064.55,
+		Tau:       4.48,
+		G:         [9]float64{23.5, -52.1, 81.3, -44.2, 9.7, 0, 0, 0, 0},
+	}
+	var incomeJSON = `
+{"tradedate": "2022-03-04", "tradetime": "18:59:59", "B1": 1112.81, "B2": 858.66, "B3": -1064.55, "T1": 4.48, "G1": 23.5, "G2": -52.1, "G3": 81.3, "G4": -44.2, "G5": 9.7, "G6": 0, "G7": 0, "G8": 0, "G9": null}
+`
+	p := ZCYCParams{}
+	err := parseZCYCParamsItem([]byte(incomeJSON), &p)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := p, expectedStruct; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseZCYCParamsItemErrCases(t *testing.T) {
+	cases := []string{
+		// no tradedate
+		`{"tradedate1": "2022-03-04", "tradetime": "18:59:59", "B1": 1, "B2": 1, "B3": 1, "T1": 1, "G1": 0, "G2": 0, "G3": 0, "G4": 0, "G5": 0, "G6": 0, "G7": 0, "G8": 0, "G9": 0}`,
+		// no B3
+		`{"tradedate": "2022-03-04", "tradetime": "18:59:59", "B1": 1, "B2": 1, "T1": 1, "G1": 0, "G2": 0, "G3": 0, "G4": 0, "G5": 0, "G6": 0, "G7": 0, "G8": 0, "G9": 0}`,
+		// no G9
+		`{"tradedate": "2022-03-04", "tradetime": "18:59:59", "B1": 1, "B2": 1, "B3": 1, "T1": 1, "G1": 0, "G2": 0, "G3": 0, "G4": 0, "G5": 0, "G6": 0, "G7": 0, "G8": 0}`,
+	}
+	for i, c := range cases {
+		p := ZCYCParams{}
+		if got, expected := parseZCYCParamsItem([]byte(c), &p), jsonparser.KeyPathNotFoundError; got != expected {
+			t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead in %d case", expected, got, i)
+		}
+	}
+}
+
+func TestParseZCYCYearYieldsUnexpectedDataTypeError(t *testing.T) {
+	var incomeJSON = `[[]]`
+	yields := make([]ZCYCYearYield, 0)
+	if got, expected := parseZCYCYearYields([]byte(incomeJSON), &yields), ErrUnexpectedDataType; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseZCYCResponse(t *testing.T) {
+	byteValue, err := getTestingData("zcyc.json")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	zr := ZCYCResponse{}
+	err = parseZCYCResponse(byteValue, &zr)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := len(zr.Params), 1; got != expected {
+		t.Fatalf("Error: expecting: \n %v params\ngot:\n %v params\ninstead", expected, got)
+	}
+	if got, expected := len(zr.YearYields), 12; got != expected {
+		t.Fatalf("Error: expecting: \n %v points\ngot:\n %v points\ninstead", expected, got)
+	}
+	expectedPoint := ZCYCYearYield{TradeDate: "2022-03-04", TradeTime: "18:59:59", Period: 0.25, Value: 21.2}
+	if got, expected := zr.YearYields[0], expectedPoint; got != expected {
+		t.Fatalf("Error: expecting: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseZCYCResponseNilError(t *testing.T) {
+	var zcycResponse *ZCYCResponse = nil
+	if got, expected := parseZCYCResponse([]byte(``), zcycResponse), ErrNilPointer; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestParseZCYCResponseError(t *testing.T) {
+	var incomeJSON = `
+[
+  {"charsetinfo": {"name": "utf-8"}},
+  {
+    "params": [],
+    "yearyields": [
+      {"tradedate": "2022-03-04", "tradetime": "18:59:59", "period1": 0.25, "value": 21.2}]}
+]
+`
+	if got, expected := parseZCYCResponse([]byte(incomeJSON), &ZCYCResponse{}), jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting error: \n %v \ngot:\n %v \ninstead", expected, got)
+	}
+}
+
+func TestZCYCParams_Yield(t *testing.T) {
+	byteValue, err := getTestingData("zcyc.json")
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	zr := ZCYCResponse{}
+	if err = parseZCYCResponse(byteValue, &zr); err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	p := zr.Params[0]
+	// the tabulated points are rounded to 0.01
+	for _, point := range zr.YearYields {
+		if got, expected := p.Yield(point.Period), point.Value; math.Abs(got-expected) > 0.005 {
+			t.Fatalf("Error: expecting %v yield for %v tenor \ngot %v \ninstead", expected, point.Period, got)
+		}
+	}
+}
+
+func TestZCYCParams_RateZeroTenor(t *testing.T) {
+	p := ZCYCParams{Beta0: 800, Beta1: -100, Beta2: 50, Tau: 2, G: [9]float64{10}}
+	if got, expected := p.Rate(0), 710.0; got != expected {
+		t.Fatalf("Error: expecting %v \ngot %v \ninstead", expected, got)
+	}
+	if got, expected := p.Rate(-1), 710.0; got != expected {
+		t.Fatalf("Error: expecting %v \ngot %v \ninstead", expected, got)
+	}
+	if got := p.Rate(1e-9); math.Abs(got-710) > 1e-3 {
+		t.Fatalf("Error: expecting %v \ngot %v \ninstead", 710.0, got)
+	}
+}
+
+func TestZCYCParams_DiscountFactor(t *testing.T) {
+	p := ZCYCParams{Beta0: 1000, Tau: 1}
+	if got, expected := p.DiscountFactor(0), 1.0; got != expected {
+		t.Fatalf("Error: expecting %v \ngot %v \ninstead", expected, got)
+	}
+	if got, expected := p.DiscountFactor(2), math.Exp(-0.2); math.Abs(got-expected) > 1e-12 {
+		t.Fatalf("Error: expecting %v \ngot %v \ninstead", expected, got)
+	}
+	// the discount factor must agree with the effective annual yield
+	if got, expected := p.DiscountFactor(2), math.Pow(1+p.Yield(2)/100, -2); math.Abs(got-expected) > 1e-12 {
+		t.Fatalf("Error: expecting %v \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestZCYCService_GetZCYC(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		byteValue, err := getTestingData("zcyc.json")
+		if err != nil {
+			t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+		}
+		_, _ = w.Write(byteValue)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	result, err := c.ZCYC.GetZCYC(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := gotPath, "/engines/stock/zcyc.json"; got != expected {
+		t.Fatalf("Error: expecting %v path \ngot %v \ninstead", expected, got)
+	}
+	if got, expected := len(result.YearYields), 12; got != expected {
+		t.Fatalf("Error: expecting: \n %v items\ngot:\n %v items\ninstead", expected, got)
+	}
+
+	_, err = c.ZCYC.GetZCYCHistory(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error: expecting <nil> error: \ngot %v \ninstead", err)
+	}
+	if got, expected := gotPath, "/history/engines/stock/zcyc.json"; got != expected {
+		t.Fatalf("Error: expecting %v path \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestZCYCService_KeyPathNotFound(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	_, err := c.ZCYC.GetZCYC(context.Background(), nil)
+	if got, expected := err, jsonparser.KeyPathNotFoundError; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}
+
+func TestZCYCService_BadUrl(t *testing.T) {
+	srv := getEmptySrv()
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL)
+	_, err := c.ZCYC.GetZCYCHistory(context.Background(), nil)
+	if got, expected := err, "BaseURL must have a trailing slash, but \""+srv.URL+"\" does not"; got == nil || got.Error() != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v  \ninstead", expected, got)
+	}
+}
+
+func TestZCYCNilContextError(t *testing.T) {
+	c := NewClient(nil)
+	var ctx context.Context = nil
+	_, err := c.ZCYC.GetZCYC(ctx, nil)
+	if got, expected := err, ErrNonNilContext; got != expected {
+		t.Fatalf("Error: expecting %v error \ngot %v \ninstead", expected, got)
+	}
+}